@@ -2,10 +2,14 @@ package bevi
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"os/signal"
+	"slices"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/mlange-42/ark/ecs"
 	"github.com/oriumgames/bevi/internal/event"
@@ -14,13 +18,25 @@ import (
 
 // App is the primary entry point for constructing and running a Bevi
 // application. It owns the ECS world, the system scheduler, the per-frame
-// event bus and the diagnostics adapter. All configuration methods return *App
-// to enable chaining before calling Run().
+// event bus, the deferred command buffers and the diagnostics adapter. All
+// configuration methods return *App to enable chaining before calling Run().
 type App struct {
 	world  *World
 	sched  *scheduler.Scheduler
 	events *event.Bus
 	diag   *internalDiagnostics
+	cmds   *commandQueues
+	cmd    Commands
+
+	observers observers
+	changes   changes
+
+	// exec is held while stages run, serializing them with immediate
+	// one-shot runs from outside the schedule.
+	exec    sync.Mutex
+	ctx     context.Context
+	clock   Clock
+	runMode RunMode
 }
 
 // NewApp constructs a new App with an empty ECS world, a scheduler and a fresh
@@ -35,13 +51,21 @@ func NewApp() *App {
 
 	bus.SetDiagnostics(diag)
 	sched.SetDiagnostics(diag)
+	sched.SetEventTypes(bus.Types)
 
-	return &App{
+	a := &App{
 		world:  &w,
 		sched:  sched,
 		events: bus,
 		diag:   diag,
+		cmds:   &commandQueues{},
+		ctx:    context.Background(),
 	}
+	a.cmd = NewCommands(a)
+	sched.SetSyncPoint(func(context.Context, any) {
+		a.applyCommands()
+	})
+	return a
 }
 
 // AddPlugin invokes the given Plugin's Build method, allowing the plugin to
@@ -63,7 +87,9 @@ func (a *App) AddPlugins(l []Plugin) *App {
 // AddSystem registers a single system function for the specified stage with
 // the provided scheduling metadata. The supplied fn must accept (context.Context,
 // *World). The meta.Access field is used to compute parallel batches and
-// dependency conflict checks.
+// dependency conflict checks, and its EventReads and EventMutates are declared
+// as readers on the event bus. Systems added to the OneShot stage are keyed by
+// name and only run through RunSystem, QueueSystem or Commands.RunSystem.
 func (a *App) AddSystem(stage Stage, name string, meta SystemMeta, fn func(context.Context, *World)) *App {
 	sys := &scheduler.System{
 		Name:  name,
@@ -73,10 +99,63 @@ func (a *App) AddSystem(stage Stage, name string, meta SystemMeta, fn func(conte
 		},
 		Meta: meta.toInternal(),
 	}
+	for _, t := range slices.Concat(sys.Meta.Access.EventReads, sys.Meta.Access.EventMutates) {
+		a.events.DeclareReader(t)
+	}
+	if stage == OneShot {
+		a.sched.AddOneShot(sys)
+		return a
+	}
 	a.sched.AddSystem(sys)
 	return a
 }
 
+// RunSystem runs the one-shot system registered under id immediately and
+// exclusively, waiting for any running stage to finish first. input is made
+// available to the system through InputFrom. Commands recorded by the system
+// are applied before RunSystem returns. Errors (unknown id, recovered panics)
+// are returned and also reported to diagnostics.
+//
+// RunSystem must not be called from within a system, as it would wait for the
+// running stage forever; use QueueSystem or Commands.RunSystem instead.
+func (a *App) RunSystem(id string, input any) error {
+	a.exec.Lock()
+	defer a.exec.Unlock()
+	err := a.runOneShot(id, input)
+	a.applyCommands()
+	return err
+}
+
+// QueueSystem schedules the one-shot system registered under id to run at the
+// next sync point. It is safe to call from systems and other goroutines, and
+// wakes a Reactive App.
+func (a *App) QueueSystem(id string, input any) {
+	a.cmd.RunSystem(id, input)
+	a.Wake()
+}
+
+// runOneShot runs a one-shot system. Callers must hold exclusive world access.
+// An unknown id is reported to diagnostics as a failed OneShot system, like
+// the errors of systems that ran.
+func (a *App) runOneShot(id string, input any) error {
+	ctx := withSystemInput(a.ctx, input)
+	err := a.sched.RunOneShot(ctx, id, a.world)
+	if errors.Is(err, scheduler.ErrUnknownOneShot) {
+		a.diag.SystemEnd(id, scheduler.Stage(OneShot), err, 0)
+	}
+	return err
+}
+
+// AddSyncPoint registers an explicit sync point in the given stage. It runs as
+// an exclusive system applying all recorded Commands, and can be ordered with
+// meta.Before/After/Set like any other system.
+func (a *App) AddSyncPoint(stage Stage, name string, meta SystemMeta) *App {
+	meta.Access.Exclusive = true
+	return a.AddSystem(stage, name, meta, func(context.Context, *World) {
+		a.applyCommands()
+	})
+}
+
 // AddSystems executes a registration callback that may add multiple systems
 // (commonly a generated Systems function). Returns the App for chaining.
 func (a *App) AddSystems(reg func(*App)) *App {
@@ -84,6 +163,51 @@ func (a *App) AddSystems(reg func(*App)) *App {
 	return a
 }
 
+// SetExecMode selects parallel (default) or sequential execution of every
+// stage. No system code has to change. Returns the App for chaining.
+func (a *App) SetExecMode(mode ExecMode) *App {
+	switch mode {
+	case Sequential:
+		a.sched.SetExecMode(scheduler.Sequential)
+	default:
+		a.sched.SetExecMode(scheduler.Parallel)
+	}
+	return a
+}
+
+// SetRunMode selects whether Run executes frames back to back (Continuous,
+// the default) or only when there is work (Reactive). Returns the App for
+// chaining.
+func (a *App) SetRunMode(mode RunMode) *App {
+	a.runMode = mode
+	return a
+}
+
+// Wake makes a Reactive App run a frame: right away if it is parked, or
+// after the current one otherwise. Events emitted from outside the schedule
+// wake it on their own; call Wake after other changes systems must see, such
+// as Commands recorded from another goroutine. It is safe to call from any
+// goroutine and does nothing in Continuous mode.
+func (a *App) Wake() {
+	a.events.Wake()
+}
+
+// SetClock installs the time source used for Every gating, diagnostics
+// durations and EventWriter.EmitAfter delays. Clocks with an Advance method,
+// such as FrameClock, are advanced at the end of every frame. Passing nil
+// restores the wall clock. Returns the App for chaining.
+func (a *App) SetClock(c Clock) *App {
+	a.clock = c
+	if c == nil {
+		a.sched.SetClock(nil)
+		a.events.SetClock(nil)
+	} else {
+		a.sched.SetClock(c.Now)
+		a.events.SetClock(c.Now)
+	}
+	return a
+}
+
 // SetDiagnostics installs an implementation to receive system execution timing
 // and error diagnostics. Passing a nil Diagnostics leaves the previous value
 // in place (no change). Returns the App for chaining.
@@ -103,6 +227,9 @@ func (a *App) Run() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	defer a.sched.Shutdown()
+	a.exec.Lock()
+	a.ctx = ctx
+	a.exec.Unlock()
 
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
@@ -115,6 +242,7 @@ func (a *App) Run() {
 	a.runStage(ctx, PreStartup)
 	a.runStage(ctx, Startup)
 	a.runStage(ctx, PostStartup)
+	a.events.CompleteNoReader()
 	a.events.Advance()
 
 	for {
@@ -124,12 +252,71 @@ func (a *App) Run() {
 		a.runStage(ctx, PreUpdate)
 		a.runStage(ctx, Update)
 		a.runStage(ctx, PostUpdate)
+		a.events.CompleteNoReader()
 		a.events.Advance()
+		if c, ok := a.clock.(frameAdvancer); ok {
+			c.Advance()
+		}
+		if a.runMode == Reactive && !a.park(ctx) {
+			return
+		}
+	}
+}
+
+// park blocks a Reactive App until the next frame has work: an event was
+// written, a system's Every deadline or an EmitAfter event is due, or Wake
+// was called. It returns false if ctx ends first. A clock that only moves
+// with frames, such as FrameClock, cannot reach a deadline while parked, so
+// pending deadlines keep frames running.
+func (a *App) park(ctx context.Context) bool {
+	wake, ok := a.events.Park()
+	if !ok {
+		return true
+	}
+	defer a.events.Unpark()
+
+	deadline, due := a.sched.NextRun()
+	if at, ok := a.events.NextRelease(); ok && (!due || at.Before(deadline)) {
+		deadline, due = at, true
+	}
+	var timeout <-chan time.Time
+	if due {
+		if _, ok := a.clock.(frameAdvancer); ok {
+			return true
+		}
+		now := time.Now()
+		if a.clock != nil {
+			now = a.clock.Now()
+		}
+		d := deadline.Sub(now)
+		if d <= 0 {
+			return true
+		}
+		t := time.NewTimer(d)
+		defer t.Stop()
+		timeout = t.C
 	}
+
+	select {
+	case <-wake:
+	case <-timeout:
+	case <-ctx.Done():
+		return false
+	}
+	return true
 }
 
 func (a *App) runStage(ctx context.Context, stage Stage) {
+	a.exec.Lock()
+	defer a.exec.Unlock()
 	a.sched.RunStage(ctx, scheduler.Stage(stage), a.world)
+	a.applyCommands()
+}
+
+// applyCommands applies all recorded Commands. Callers must guarantee that no
+// system is running concurrently.
+func (a *App) applyCommands() {
+	a.cmds.apply(a.world)
 }
 
 func (a *App) World() *World {
@@ -140,6 +327,13 @@ func (a *App) Events() *event.Bus {
 	return a.events
 }
 
+// Commands returns the App's own command buffer, for recording world changes
+// from outside the schedule (e.g. network goroutines). It is applied at the
+// next sync point.
+func (a *App) Commands() Commands {
+	return a.cmd
+}
+
 type Plugin interface {
 	Build(app *App)
 }
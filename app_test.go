@@ -0,0 +1,210 @@
+package bevi_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oriumgames/bevi"
+)
+
+// stopRun interrupts App.Run the way SIGINT does and waits until the run's
+// context, passed to systems as ctx, is cancelled. It must be called from a
+// system, since Run only handles the signal while it is running.
+func stopRun(ctx context.Context, t *testing.T) {
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(os.Interrupt)
+	}
+	if err != nil {
+		t.Errorf("interrupt: %v", err)
+		return
+	}
+	<-ctx.Done()
+}
+
+// goid returns the ID of the calling goroutine.
+func goid() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, _ := strconv.ParseUint(string(buf[:bytes.IndexByte(buf, ' ')]), 10, 64)
+	return id
+}
+
+// Test that a Sequential App runs the systems of a stage in topological
+// order on the goroutine calling Run, identically across runs.
+func TestSequentialDeterminism(t *testing.T) {
+	const frames = 3
+	run := func() []string {
+		var (
+			mu    sync.Mutex
+			order []string
+		)
+		caller := goid()
+		record := func(name string) func(context.Context, *bevi.World) {
+			return func(ctx context.Context, _ *bevi.World) {
+				if id := goid(); id != caller {
+					t.Errorf("%s ran on goroutine %d, want %d", name, id, caller)
+				}
+				mu.Lock()
+				order = append(order, name)
+				n := len(order)
+				mu.Unlock()
+				if n == 4*frames {
+					stopRun(ctx, t)
+				}
+			}
+		}
+		// Registered out of order; none of the systems conflict, so a
+		// Parallel App would be free to run them concurrently.
+		app := bevi.NewApp().SetExecMode(bevi.Sequential)
+		app.AddSystem(bevi.Update, "c", bevi.SystemMeta{}, record("c"))
+		app.AddSystem(bevi.Update, "a", bevi.SystemMeta{After: []string{"d"}}, record("a"))
+		app.AddSystem(bevi.Update, "b", bevi.SystemMeta{After: []string{"c"}}, record("b"))
+		app.AddSystem(bevi.Update, "d", bevi.SystemMeta{}, record("d"))
+		app.Run()
+		return order
+	}
+
+	// Ready systems are taken by name: c, then its dependent b, then d and a.
+	frame := []string{"c", "b", "d", "a"}
+	var want []string
+	for range frames {
+		want = append(want, frame...)
+	}
+	first := run()
+	if !slices.Equal(first, want) {
+		t.Fatalf("expected order %v, got %v", want, first)
+	}
+	if second := run(); !slices.Equal(second, first) {
+		t.Fatalf("expected the same order on every run, got %v and %v", first, second)
+	}
+}
+
+// offsetClock is the wall clock shifted by an offset the test can set.
+type offsetClock struct {
+	offset atomic.Int64
+}
+
+func (c *offsetClock) Now() time.Time {
+	return time.Now().Add(time.Duration(c.offset.Load()))
+}
+
+// Set shifts the clock so that Now returns t.
+func (c *offsetClock) Set(t time.Time) {
+	c.offset.Store(int64(time.Until(t)))
+}
+
+// Test that a Reactive App parks between frames and only runs frames for an
+// event emitted from outside the schedule, Wake, and the next Every deadline.
+func TestReactiveRun(t *testing.T) {
+	const quiet = 100 * time.Millisecond
+	clock := &offsetClock{}
+	app := bevi.NewApp().SetRunMode(bevi.Reactive).SetClock(clock)
+	r := bevi.ReaderFor[clicked](app.Events())
+
+	var stop atomic.Bool
+	frames := make(chan struct{}, 64)
+	ticks := make(chan time.Time, 8)
+	got := make(chan int, 8)
+	app.AddSystem(bevi.Update, "frame", bevi.SystemMeta{}, func(ctx context.Context, _ *bevi.World) {
+		if stop.Load() {
+			stopRun(ctx, t)
+			return
+		}
+		frames <- struct{}{}
+	})
+	app.AddSystem(bevi.Update, "tick", bevi.SystemMeta{Every: time.Hour}, func(context.Context, *bevi.World) {
+		ticks <- clock.Now()
+	})
+	app.AddSystem(bevi.Update, "read", bevi.SystemMeta{}, func(context.Context, *bevi.World) {
+		r.ForEach(func(ev clicked) bool {
+			got <- ev.Button
+			return true
+		})
+	})
+	done := make(chan struct{})
+	go func() {
+		app.Run()
+		close(done)
+	}()
+
+	// burst waits for a frame and returns the number of frames run until the
+	// App stays parked for quiet.
+	burst := func(why string) int {
+		t.Helper()
+		select {
+		case <-frames:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: expected a frame", why)
+		}
+		n := 1
+		for {
+			select {
+			case <-frames:
+				n++
+			case <-time.After(quiet):
+				return n
+			}
+		}
+	}
+
+	if n := burst("start"); n != 1 {
+		t.Fatalf("start: expected 1 frame before parking, got %d", n)
+	}
+	var ticked time.Time
+	select {
+	case ticked = <-ticks:
+	default:
+		t.Fatal("expected the gated system to run in the first frame")
+	}
+
+	// One frame publishes the event, the next lets systems read it.
+	bevi.WriterFor[clicked](app.Events()).Emit(clicked{Button: 1})
+	if n := burst("emit"); n != 2 {
+		t.Fatalf("emit: expected 2 frames, got %d", n)
+	}
+	select {
+	case b := <-got:
+		if b != 1 {
+			t.Fatalf("expected button 1, got %d", b)
+		}
+	default:
+		t.Fatal("expected the emitted event to be read")
+	}
+
+	// Move the gated system's deadline close; the Wake frame comes too early
+	// for it, and the App then wakes on its own once it is due.
+	clock.Set(ticked.Add(time.Hour - 3*quiet))
+	app.Wake()
+	if n := burst("wake"); n != 1 {
+		t.Fatalf("wake: expected 1 frame, got %d", n)
+	}
+	if len(ticks) != 0 {
+		t.Fatal("expected the gated system not to run before its deadline")
+	}
+	select {
+	case <-ticks:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a frame at the Every deadline")
+	}
+	if n := burst("deadline"); n != 1 {
+		t.Fatalf("deadline: expected 1 frame, got %d", n)
+	}
+
+	stop.Store(true)
+	app.Wake()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Run to return")
+	}
+}
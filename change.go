@@ -0,0 +1,346 @@
+package bevi
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mlange-42/ark/ecs"
+)
+
+// TrackChanges enables change detection for component C: bevi records when C
+// is added to or changed on each entity and when it is removed. Filters using
+// Added or Changed and RemovedComponents readers enable it for their types,
+// so calling it directly is only needed for Ref.Added/Ref.Changed on types no
+// filter tracks. Tracking must be enabled before the App runs.
+func TrackChanges[C any](app *App) *App {
+	app.changes.track(app.world, ecs.C[C]())
+	return app
+}
+
+// Ref gives change-tracked access to a component of the current query entity.
+// Obtain it from a query's Refs method.
+type Ref[T any] struct {
+	ptr   *T
+	e     Entity
+	tr    *tracker
+	since uint64
+	this  uint64
+}
+
+// Get returns the component for reading. Writes through the pointer are not
+// detected; use Mut for that.
+func (r Ref[T]) Get() *T {
+	return r.ptr
+}
+
+// Mut returns the component for writing and marks it as changed.
+func (r Ref[T]) Mut() *T {
+	if r.tr != nil {
+		r.tr.changed(r.e, r.this)
+	}
+	return r.ptr
+}
+
+// Added reports whether the component was added since the previous query of
+// the same filter. It is always false for untracked components.
+func (r Ref[T]) Added() bool {
+	if r.tr == nil {
+		return false
+	}
+	t, _ := r.tr.get(r.e)
+	return t.added > r.since
+}
+
+// Changed reports whether the component was added or changed since the
+// previous query of the same filter. It is always false for untracked
+// components.
+func (r Ref[T]) Changed() bool {
+	if r.tr == nil {
+		return false
+	}
+	t, _ := r.tr.get(r.e)
+	return t.changed > r.since
+}
+
+// RemovedComponents reads the entities that lost component T, by removal or
+// despawn, since the reader's previous ForEach. Like event readers, each
+// reader owns its cursor, so systems that skip frames still see every removal.
+// A RemovedComponents must not be used concurrently.
+type RemovedComponents[T any] struct {
+	tr     *tracker
+	cursor *atomic.Uint64
+}
+
+// NewRemovedComponents creates a removal reader for T and enables change
+// detection for it.
+func NewRemovedComponents[T any](app *App) *RemovedComponents[T] {
+	tr := app.changes.track(app.world, ecs.C[T]())
+	return &RemovedComponents[T]{tr: tr, cursor: tr.newCursor(app.changes.tick.Load())}
+}
+
+// ForEach calls fn for every entity that lost T since the previous call. The
+// entities are usually dead or no longer have T. fn returns false to stop
+// early; the remaining removals are delivered by the next call.
+func (r *RemovedComponents[T]) ForEach(fn func(e Entity) bool) {
+	if r.tr == nil {
+		return
+	}
+	last := r.cursor.Load()
+	for _, rm := range r.tr.removals(last) {
+		last = rm.tick
+		if !fn(rm.e) {
+			break
+		}
+	}
+	r.cursor.Store(last)
+	r.tr.prune()
+}
+
+// Len returns the number of removals not yet read.
+func (r *RemovedComponents[T]) Len() int {
+	if r.tr == nil {
+		return 0
+	}
+	return len(r.tr.removals(r.cursor.Load()))
+}
+
+// changes holds the App's change tick and the trackers of components with
+// change detection. The tick is a counter advanced by every query and
+// tracked structural change; a change is visible to a query if it was stamped
+// after the query's previous run.
+type changes struct {
+	tick     atomic.Uint64
+	mu       sync.RWMutex
+	trackers map[reflect.Type]*tracker
+}
+
+// track returns the tracker for comp, creating it and registering its Ark
+// observers on first use.
+func (c *changes) track(w *World, comp Component) *tracker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if tr, ok := c.trackers[comp.Type()]; ok {
+		return tr
+	}
+	if c.trackers == nil {
+		c.trackers = make(map[reflect.Type]*tracker)
+	}
+	tr := &tracker{ticks: make(map[Entity]componentTicks)}
+	c.trackers[comp.Type()] = tr
+
+	added := func(e Entity) { tr.added(e, c.tick.Add(1)) }
+	removed := func(e Entity) { tr.removed(e, &c.tick) }
+	ecs.Observe(ecs.OnCreateEntity).For(comp).Do(added).Register(w)
+	ecs.Observe(ecs.OnAddComponents).For(comp).Do(added).Register(w)
+	ecs.Observe(ecs.OnSetComponents).For(comp).Do(func(e Entity) {
+		tr.changed(e, c.tick.Add(1))
+	}).Register(w)
+	ecs.Observe(ecs.OnRemoveEntity).For(comp).Do(removed).Register(w)
+	ecs.Observe(ecs.OnRemoveComponents).For(comp).Do(removed).Register(w)
+	return tr
+}
+
+// lookup returns the tracker for t, or nil if t is not tracked.
+func (c *changes) lookup(t reflect.Type) *tracker {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.trackers[t]
+}
+
+// tracking reports whether any component is tracked.
+func (c *changes) tracking() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.trackers) > 0
+}
+
+// replaced marks components overwritten by Commands.Add as changed.
+func (c *changes) replaced(e Entity, types []reflect.Type) {
+	for _, t := range types {
+		if tr := c.lookup(t); tr != nil {
+			tr.changed(e, c.tick.Add(1))
+		}
+	}
+}
+
+// componentTicks are the change ticks of one component of one entity.
+type componentTicks struct {
+	added   uint64
+	changed uint64
+}
+
+// removal records that an entity lost the tracked component.
+type removal struct {
+	e    Entity
+	tick uint64
+}
+
+// tracker stores the change ticks of a single component type. Systems with
+// disjoint archetype filters may use the same tracker in parallel, so it is
+// guarded by a mutex.
+type tracker struct {
+	mu      sync.RWMutex
+	ticks   map[Entity]componentTicks
+	log     []removal
+	cursors []*atomic.Uint64
+}
+
+func (t *tracker) get(e Entity) (componentTicks, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	ct, ok := t.ticks[e]
+	return ct, ok
+}
+
+func (t *tracker) added(e Entity, tick uint64) {
+	t.mu.Lock()
+	t.ticks[e] = componentTicks{added: tick, changed: tick}
+	t.mu.Unlock()
+}
+
+func (t *tracker) changed(e Entity, tick uint64) {
+	t.mu.Lock()
+	ct := t.ticks[e]
+	ct.changed = max(ct.changed, tick)
+	t.ticks[e] = ct
+	t.mu.Unlock()
+}
+
+// removed forgets e's ticks and logs the removal. The tick is taken under the
+// lock so the log stays ordered.
+func (t *tracker) removed(e Entity, tick *atomic.Uint64) {
+	t.mu.Lock()
+	delete(t.ticks, e)
+	if len(t.cursors) > 0 {
+		t.log = append(t.log, removal{e: e, tick: tick.Add(1)})
+	}
+	t.mu.Unlock()
+}
+
+// newCursor registers a RemovedComponents cursor starting at tick.
+func (t *tracker) newCursor(tick uint64) *atomic.Uint64 {
+	c := &atomic.Uint64{}
+	c.Store(tick)
+	t.mu.Lock()
+	t.cursors = append(t.cursors, c)
+	t.mu.Unlock()
+	return c
+}
+
+// removals returns a copy of the removals stamped after tick.
+func (t *tracker) removals(tick uint64) []removal {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	i, _ := slices.BinarySearchFunc(t.log, tick, func(r removal, tick uint64) int {
+		if r.tick <= tick {
+			return -1
+		}
+		return 1
+	})
+	return slices.Clone(t.log[i:])
+}
+
+// prune drops the removals every cursor has read.
+func (t *tracker) prune() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	low := t.cursors[0].Load()
+	for _, c := range t.cursors[1:] {
+		low = min(low, c.Load())
+	}
+	n := 0
+	for n < len(t.log) && t.log[n].tick <= low {
+		n++
+	}
+	t.log = slices.Delete(t.log, 0, n)
+}
+
+// changeFilter holds the Added/Changed constraints of a filter and the tick
+// of its previous query, which bounds the changes the next query sees.
+type changeFilter struct {
+	app     *App
+	comps   []reflect.Type // the filter's component types, for Refs
+	added   []Component
+	changed []Component
+	last    atomic.Uint64
+}
+
+func newChangeFilter(app *App, comps ...Component) *changeFilter {
+	f := &changeFilter{app: app}
+	for _, c := range comps {
+		f.comps = append(f.comps, c.Type())
+	}
+	return f
+}
+
+func (f *changeFilter) addAdded(comps []Component) {
+	for _, c := range comps {
+		f.app.changes.track(f.app.world, c)
+	}
+	f.added = append(f.added, comps...)
+}
+
+func (f *changeFilter) addChanged(comps []Component) {
+	for _, c := range comps {
+		f.app.changes.track(f.app.world, c)
+	}
+	f.changed = append(f.changed, comps...)
+}
+
+// query starts a query, advancing the filter's change window. It returns nil
+// if the App tracks no components, so untracked queries pay nothing.
+func (f *changeFilter) query() *changeQuery {
+	if f == nil || f.app == nil || !f.app.changes.tracking() {
+		return nil
+	}
+	c := &f.app.changes
+	this := c.tick.Add(1)
+	q := &changeQuery{since: f.last.Swap(this), this: this}
+	for _, comp := range f.added {
+		q.added = append(q.added, c.lookup(comp.Type()))
+	}
+	for _, comp := range f.changed {
+		q.changed = append(q.changed, c.lookup(comp.Type()))
+	}
+	for _, t := range f.comps {
+		q.refs = append(q.refs, c.lookup(t))
+	}
+	return q
+}
+
+// changeQuery is the change window of a running query.
+type changeQuery struct {
+	added   []*tracker
+	changed []*tracker
+	refs    []*tracker // per query component; nil entries are untracked
+	since   uint64
+	this    uint64
+}
+
+// match reports whether e passes the Added and Changed constraints.
+func (q *changeQuery) match(e Entity) bool {
+	if q == nil {
+		return true
+	}
+	for _, tr := range q.added {
+		if t, _ := tr.get(e); t.added <= q.since {
+			return false
+		}
+	}
+	for _, tr := range q.changed {
+		if t, _ := tr.get(e); t.changed <= q.since {
+			return false
+		}
+	}
+	return true
+}
+
+// newRef wraps the i-th component of a query entity.
+func newRef[T any](q *changeQuery, i int, e Entity, ptr *T) Ref[T] {
+	if q == nil {
+		return Ref[T]{ptr: ptr, e: e}
+	}
+	return Ref[T]{ptr: ptr, e: e, tr: q.refs[i], since: q.since, this: q.this}
+}
@@ -0,0 +1,191 @@
+package bevi_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/oriumgames/bevi"
+)
+
+// matches runs one query of f and returns the entities it yields.
+func matches(f *bevi.Filter1[health]) []bevi.Entity {
+	var out []bevi.Entity
+	q := f.Query()
+	for q.Next() {
+		out = append(out, q.Entity())
+	}
+	return out
+}
+
+// Test that Added and Changed filters see each change exactly once, each
+// filter independently.
+func TestAddedChangedFilters(t *testing.T) {
+	app := newTestApp()
+	added := bevi.NewFilter1[health](app).Added(bevi.C[health]())
+	changed := bevi.NewFilter1[health](app).Changed(bevi.C[health]())
+	other := bevi.NewFilter1[health](app).Added(bevi.C[health]())
+	hp := ecs.NewMap[health](app.World())
+
+	e := hp.NewEntity(&health{HP: 1})
+	for name, f := range map[string]*bevi.Filter1[health]{"added": added, "changed": changed, "other": other} {
+		if got := matches(f); !slices.Equal(got, []bevi.Entity{e}) {
+			t.Fatalf("%s: expected the spawned entity, got %v", name, got)
+		}
+		if got := matches(f); len(got) != 0 {
+			t.Fatalf("%s: expected the spawn to be seen once, got %v", name, got)
+		}
+	}
+
+	// Overwriting through Commands.Add and Map.Set changes but does not add.
+	app.Commands().Add(e, health{HP: 2})
+	flush(t, app)
+	if got := matches(changed); !slices.Equal(got, []bevi.Entity{e}) {
+		t.Fatalf("expected Commands.Add to mark a change, got %v", got)
+	}
+	if got := matches(added); len(got) != 0 {
+		t.Fatalf("expected Commands.Add of a present component not to count as added, got %v", got)
+	}
+	hp.Set(e, &health{HP: 3})
+	if got := matches(changed); !slices.Equal(got, []bevi.Entity{e}) {
+		t.Fatalf("expected Map.Set to mark a change, got %v", got)
+	}
+	if got := matches(changed); len(got) != 0 {
+		t.Fatalf("expected the change to be seen once, got %v", got)
+	}
+}
+
+// Test that Ref.Mut marks a change visible to other filters but not to the
+// writing filter, while Ref.Get does not.
+func TestRefMut(t *testing.T) {
+	app := newTestApp()
+	writer := bevi.NewFilter1[health](app)
+	changed := bevi.NewFilter1[health](app).Changed(bevi.C[health]())
+	e := ecs.NewMap[health](app.World()).NewEntity(&health{HP: 1})
+	matches(changed)
+
+	q := writer.Query()
+	for q.Next() {
+		q.Refs().Get().HP = 5
+	}
+	if got := matches(changed); len(got) != 0 {
+		t.Fatalf("expected Get writes to go undetected, got %v", got)
+	}
+
+	q = writer.Query()
+	for q.Next() {
+		q.Refs().Mut().HP = 6
+	}
+	if got := matches(changed); !slices.Equal(got, []bevi.Entity{e}) {
+		t.Fatalf("expected Mut to mark a change, got %v", got)
+	}
+
+	// A changed filter writing through Mut does not see its own change.
+	self := bevi.NewFilter1[health](app).Changed(bevi.C[health]())
+	q = self.Query()
+	for q.Next() {
+		q.Refs().Mut().HP = 7
+	}
+	if got := matches(self); len(got) != 0 {
+		t.Fatalf("expected a filter not to see its own Mut, got %v", got)
+	}
+	if got := matches(changed); !slices.Equal(got, []bevi.Entity{e}) {
+		t.Fatalf("expected other filters to see the Mut, got %v", got)
+	}
+}
+
+// Test that two systems querying the same component at different Every rates
+// each see the changes since their own previous run, given one filter per
+// system as generated code does.
+func TestRefChangedPerSystem(t *testing.T) {
+	const step = 10 * time.Millisecond
+	app := bevi.NewApp().SetExecMode(bevi.Sequential)
+	app.SetClock(bevi.NewFrameClock(time.Unix(0, 0), step))
+	bevi.TrackChanges[health](app)
+	hp := ecs.NewMap[health](app.World())
+	e := hp.NewEntity(&health{HP: 1})
+
+	frame := 0
+	app.AddSystem(bevi.Update, "write", bevi.SystemMeta{}, func(ctx context.Context, _ *bevi.World) {
+		frame++
+		switch frame {
+		case 2:
+			hp.Set(e, &health{HP: 2})
+		case 8:
+			stopRun(ctx, t)
+		}
+	})
+	seen := map[string][]int{}
+	observe := func(name string) func(context.Context, *bevi.World) {
+		f := bevi.NewFilter1[health](app)
+		return func(context.Context, *bevi.World) {
+			q := f.Query()
+			for q.Next() {
+				if q.Refs().Changed() {
+					seen[name] = append(seen[name], frame)
+				}
+			}
+		}
+	}
+	app.AddSystem(bevi.Update, "fast", bevi.SystemMeta{After: []string{"write"}}, observe("fast"))
+	app.AddSystem(bevi.Update, "slow", bevi.SystemMeta{After: []string{"write"}, Every: 3 * step}, observe("slow"))
+	app.Run()
+
+	// The spawn counts as a change on each system's first run; the write in
+	// frame 2 reaches slow on its next run although fast queried in between.
+	if want := []int{1, 2}; !slices.Equal(seen["fast"], want) {
+		t.Errorf("fast: changes seen in frames %v, want %v", seen["fast"], want)
+	}
+	if want := []int{1, 4}; !slices.Equal(seen["slow"], want) {
+		t.Errorf("slow: changes seen in frames %v, want %v", seen["slow"], want)
+	}
+}
+
+// Test that removals and despawns reach every RemovedComponents reader, and
+// that a reader skipping frames still sees all of them once.
+func TestRemovedComponents(t *testing.T) {
+	app := newTestApp()
+	eager := bevi.NewRemovedComponents[health](app)
+	lagging := bevi.NewRemovedComponents[health](app)
+	hp := ecs.NewMap[health](app.World())
+	a := hp.NewEntity(&health{HP: 1})
+	b := hp.NewEntity(&health{HP: 1})
+	c := hp.NewEntity(&health{HP: 1})
+
+	read := func(r *bevi.RemovedComponents[health]) []bevi.Entity {
+		var out []bevi.Entity
+		r.ForEach(func(e bevi.Entity) bool {
+			out = append(out, e)
+			return true
+		})
+		return out
+	}
+
+	app.Commands().Remove(a, bevi.C[health]())
+	flush(t, app)
+	if got := read(eager); !slices.Equal(got, []bevi.Entity{a}) {
+		t.Fatalf("expected the removal, got %v", got)
+	}
+
+	app.Commands().Despawn(b)
+	flush(t, app)
+	if got := read(eager); !slices.Equal(got, []bevi.Entity{b}) {
+		t.Fatalf("expected the despawn, got %v", got)
+	}
+	app.World().RemoveEntity(c)
+	if got := read(eager); !slices.Equal(got, []bevi.Entity{c}) {
+		t.Fatalf("expected the direct despawn, got %v", got)
+	}
+	if got := read(eager); len(got) != 0 {
+		t.Fatalf("expected every removal to be read once, got %v", got)
+	}
+
+	if n := lagging.Len(); n != 3 {
+		t.Fatalf("expected 3 unread removals for the lagging reader, got %d", n)
+	}
+	if got := read(lagging); !slices.Equal(got, []bevi.Entity{a, b, c}) {
+		t.Fatalf("expected the lagging reader to see every removal, got %v", got)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"go/token"
 	"path"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -87,6 +88,7 @@ func (SystemTagAnalyzer) Run(ctx *Context) error {
 					ExtraImports:       make(map[string]string),
 					DerivedAliasCounts: make(map[string]int),
 					FilterByParam:      make(map[string]FilterOptions),
+					ReaderByParam:      make(map[string]ReaderOptions),
 				}
 				if err := parseOptionsInto(optsStr, sys); err != nil {
 					return fmt.Errorf("parse options for %s: %w", sys.FuncName, err)
@@ -138,7 +140,7 @@ func (SystemTagAnalyzer) Run(ctx *Context) error {
 					sys.ExtraImports[resolved] = ip
 				}
 
-				// Parse //bevi:filter DSL lines: "<target> [+Type|-Type|!exclusive|!register]..."
+				// Parse //bevi:filter DSL lines: "<target> [+Type|-Type|~Type|Added[Type]|Changed[Type]|!exclusive|!register]..."
 				for _, c := range fd.Doc.List {
 					txt := strings.TrimPrefix(c.Text, "//")
 					txt = strings.TrimPrefix(txt, "/*")
@@ -157,39 +159,43 @@ func (SystemTagAnalyzer) Run(ctx *Context) error {
 					}
 					target := toks[0] // parameter name or positional like Q0/F0
 					opts := sys.FilterByParam[target]
+					// Rewrite alias if qualified and known; warn if unknown alias used
+					qualify := func(ty string) string {
+						if dot := strings.IndexByte(ty, '.'); dot > 0 {
+							al := ty[:dot]
+							name := ty[dot+1:]
+							if res, ok := aliasMap[al]; ok && res != "" {
+								return res + "." + name
+							} else if _, ok := sys.ExtraImports[al]; !ok {
+								ctx.Logger("filter type %q references unknown import alias %q in %s (%s)", ty, al, sys.FuncName, gf.Path)
+							}
+						}
+						return ty
+					}
 					for _, tk := range toks[1:] {
 						if tk == "" {
 							continue
 						}
 						switch {
+						case strings.HasPrefix(tk, "Added[") && strings.HasSuffix(tk, "]"):
+							if ty := strings.TrimSpace(tk[len("Added[") : len(tk)-1]); ty != "" {
+								opts.Added = append(opts.Added, qualify(ty))
+							}
+						case strings.HasPrefix(tk, "Changed[") && strings.HasSuffix(tk, "]"):
+							if ty := strings.TrimSpace(tk[len("Changed[") : len(tk)-1]); ty != "" {
+								opts.Changed = append(opts.Changed, qualify(ty))
+							}
 						case strings.HasPrefix(tk, "+"):
-							ty := strings.TrimSpace(strings.TrimPrefix(tk, "+"))
-							if ty != "" {
-								// Rewrite alias if qualified and known; warn if unknown alias used
-								if dot := strings.IndexByte(ty, '.'); dot > 0 {
-									al := ty[:dot]
-									name := ty[dot+1:]
-									if res, ok := aliasMap[al]; ok && res != "" {
-										ty = res + "." + name
-									} else if _, ok := sys.ExtraImports[al]; !ok {
-										ctx.Logger("filter type %q references unknown import alias %q in %s (%s)", ty, al, sys.FuncName, gf.Path)
-									}
-								}
-								opts.With = append(opts.With, ty)
+							if ty := strings.TrimSpace(strings.TrimPrefix(tk, "+")); ty != "" {
+								opts.With = append(opts.With, qualify(ty))
 							}
 						case strings.HasPrefix(tk, "-"):
-							ty := strings.TrimSpace(strings.TrimPrefix(tk, "-"))
-							if ty != "" {
-								if dot := strings.IndexByte(ty, '.'); dot > 0 {
-									al := ty[:dot]
-									name := ty[dot+1:]
-									if res, ok := aliasMap[al]; ok && res != "" {
-										ty = res + "." + name
-									} else if _, ok := sys.ExtraImports[al]; !ok {
-										ctx.Logger("filter type %q references unknown import alias %q in %s (%s)", ty, al, sys.FuncName, gf.Path)
-									}
-								}
-								opts.Without = append(opts.Without, ty)
+							if ty := strings.TrimSpace(strings.TrimPrefix(tk, "-")); ty != "" {
+								opts.Without = append(opts.Without, qualify(ty))
+							}
+						case strings.HasPrefix(tk, "~"):
+							if ty := strings.TrimSpace(strings.TrimPrefix(tk, "~")); ty != "" {
+								opts.Relations = append(opts.Relations, qualify(ty))
 							}
 						case tk == "!exclusive":
 							opts.Exclusive = true
@@ -200,6 +206,33 @@ func (SystemTagAnalyzer) Run(ctx *Context) error {
 					sys.FilterByParam[target] = opts
 				}
 
+				// Parse //bevi:reader DSL lines: "<target> [Priority] [!ignorecancelled]"
+				for _, c := range fd.Doc.List {
+					txt := strings.TrimPrefix(c.Text, "//")
+					txt = strings.TrimSpace(txt)
+					if !strings.HasPrefix(txt, "bevi:reader") {
+						continue
+					}
+					toks := splitTopLevel(strings.TrimSpace(strings.TrimPrefix(txt, "bevi:reader")))
+					if len(toks) == 0 {
+						continue
+					}
+					target := toks[0] // parameter name or positional like R0
+					opts := sys.ReaderByParam[target]
+					for _, tk := range toks[1:] {
+						if tk == "!ignorecancelled" {
+							opts.IgnoreCancelled = true
+							continue
+						}
+						if i := slices.IndexFunc(readerPriorities, func(p string) bool { return strings.EqualFold(p, tk) }); i >= 0 {
+							opts.Priority = readerPriorities[i]
+							continue
+						}
+						ctx.Logger("unknown reader option %q for %s in %s (%s)", tk, target, sys.FuncName, gf.Path)
+					}
+					sys.ReaderByParam[target] = opts
+				}
+
 				// Attach to package (Package exposes SysSpecs and addSystem).
 				pkg.addSystem(sys)
 			}
@@ -214,7 +247,7 @@ func parseOptionsInto(opts string, out *System) error {
 		return nil
 	}
 	// Options format: Key=Value whitespace separated.
-	// Keys: Every, After, Before, Set, Reads, Writes, ResReads, ResWrites
+	// Keys: Every, After, Before, Set, Reads, Writes, ResReads, ResWrites, RelReads, RelWrites
 	toks := splitTopLevel(opts)
 	for _, tok := range toks {
 		kv := strings.SplitN(tok, "=", 2)
@@ -268,6 +301,18 @@ func parseOptionsInto(opts string, out *System) error {
 				return fmt.Errorf("ResWrites=%q: %w", val, err)
 			}
 			out.ResWrites = items
+		case "relreads":
+			items, err := parseStringArray(val)
+			if err != nil {
+				return fmt.Errorf("RelReads=%q: %w", val, err)
+			}
+			out.RelReads = items
+		case "relwrites":
+			items, err := parseStringArray(val)
+			if err != nil {
+				return fmt.Errorf("RelWrites=%q: %w", val, err)
+			}
+			out.RelWrites = items
 		default:
 			return fmt.Errorf("unknown option %q", key)
 		}
@@ -365,12 +410,34 @@ func (ParamInferAnalyzer) Run(ctx *Context) error {
 							}
 						}
 					}
+					// Bind per-parameter reader options from //bevi:reader lines by name or positional index (Rk)
+					ri := 0
+					for i := range sys.Params {
+						switch sys.Params[i].Kind {
+						case ParamEventReader, ParamRequestReader:
+							key := fmt.Sprintf("R%d", ri)
+							ri++
+							if ro, ok := sys.ReaderByParam[sys.Params[i].Name]; ok && sys.Params[i].Name != "" {
+								sys.Params[i].ReaderOpts = ro
+								matched["reader:"+sys.Params[i].Name] = true
+							} else if ro, ok := sys.ReaderByParam[key]; ok {
+								sys.Params[i].ReaderOpts = ro
+								matched["reader:"+key] = true
+							}
+						}
+					}
+					for k := range sys.ReaderByParam {
+						if !matched["reader:"+k] {
+							ctx.Logger("unknown reader target %q for system %s (%s)", k, sys.FuncName, sys.FilePath)
+						}
+					}
 					// Diagnostics for unknown filter targets (typos or no matching param)
 					for k := range sys.FilterByParam {
 						if !matched[k] {
 							ctx.Logger("unknown filter target %q for system %s (%s)", k, sys.FuncName, sys.FilePath)
 						}
 					}
+					inferCommandWrites(sys, fd.Body)
 				}
 				return true
 			})
@@ -379,6 +446,114 @@ func (ParamInferAnalyzer) Run(ctx *Context) error {
 	return nil
 }
 
+// inferCommandWrites scans body for calls recorded on the system's Commands
+// parameters and stores the component and resource types they write on the
+// parameter. Only arguments whose type is visible in the expression itself
+// (&T{}, T{}, new(T) and bevi.C[T]()) are recognized.
+func inferCommandWrites(sys *System, body *ast.BlockStmt) {
+	if body == nil {
+		return
+	}
+	idx := make(map[string]int)
+	for i, p := range sys.Params {
+		if p.Kind == ParamCommands && p.Name != "" && p.Name != "_" {
+			idx[p.Name] = i
+		}
+	}
+	if len(idx) == 0 {
+		return
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		recv, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		i, ok := idx[recv.Name]
+		if !ok {
+			return true
+		}
+		p := &sys.Params[i]
+		args := call.Args
+		switch sel.Sel.Name {
+		case "Spawn":
+		case "SpawnFn", "Add", "Remove":
+			if len(args) == 0 {
+				return true
+			}
+			args = args[1:]
+		case "InsertResource":
+			for _, a := range args {
+				if t := argType(a); t != "" && !slices.Contains(p.CmdResWrites, t) {
+					p.CmdResWrites = append(p.CmdResWrites, t)
+				}
+			}
+			return true
+		default:
+			return true
+		}
+		for _, a := range args {
+			if t := argType(a); t != "" && !slices.Contains(p.CmdWrites, t) {
+				p.CmdWrites = append(p.CmdWrites, t)
+			}
+		}
+		return true
+	})
+}
+
+// argType returns the type written by a Commands argument, or "" if it
+// cannot be read off the expression.
+func argType(e ast.Expr) string {
+	var t ast.Expr
+	switch x := e.(type) {
+	case *ast.UnaryExpr:
+		if lit, ok := x.X.(*ast.CompositeLit); ok && x.Op == token.AND {
+			t = lit.Type
+		}
+	case *ast.CompositeLit:
+		t = x.Type
+	case *ast.CallExpr:
+		switch fn := x.Fun.(type) {
+		case *ast.Ident:
+			if fn.Name == "new" && len(x.Args) == 1 {
+				t = x.Args[0]
+			}
+		case *ast.IndexExpr:
+			if name := exprName(fn.X); name == "C" || name == "bevi.C" {
+				t = fn.Index
+			}
+		}
+	}
+	if t == nil {
+		return ""
+	}
+	var b bytes.Buffer
+	if err := format.Node(&b, token.NewFileSet(), t); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// exprName renders an identifier or qualified identifier, or "" otherwise.
+func exprName(e ast.Expr) string {
+	switch x := e.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		if id, ok := x.X.(*ast.Ident); ok {
+			return id.Name + "." + x.Sel.Name
+		}
+	}
+	return ""
+}
+
 func indexSystemsByFileFunc(pkg *Package) (map[string]*System, error) {
 	m := make(map[string]*System, len(pkg.SysSpecs))
 	for _, s := range pkg.SysSpecs {
@@ -436,6 +611,16 @@ func inferParam(expr ast.Expr) Param {
 		p.Kind = ParamEventWriter
 	case typeName == "bevi.EventReader":
 		p.Kind = ParamEventReader
+	case typeName == "bevi.RequestWriter":
+		p.Kind = ParamRequestWriter
+	case typeName == "bevi.RequestReader":
+		p.Kind = ParamRequestReader
+	case typeName == "bevi.RemovedComponents":
+		p.Kind = ParamRemovedComponents
+	case typeName == "bevi.Commands":
+		p.Kind = ParamCommands
+	case typeName == "bevi.In" && !p.Pointer:
+		p.Kind = ParamInput
 	default:
 		p.Kind = ParamUnknown
 	}
@@ -462,10 +647,21 @@ func inferParam(expr ast.Expr) Param {
 			prefix = "ew:"
 		case ParamEventReader:
 			prefix = "er:"
+		case ParamRequestWriter:
+			prefix = "rw:"
+		case ParamRequestReader:
+			prefix = "rr:"
+		case ParamRemovedComponents:
+			prefix = "rm:"
 		}
 		if prefix != "" {
 			p.HelperKey = prefix + strings.Join(p.ElemTypes, ",")
 		}
+	} else if typeName == "bevi.Query0" {
+		// Relation-only or filter-only queries carry no component types.
+		p.HelperKey = "query:"
+	} else if typeName == "bevi.Filter0" {
+		p.HelperKey = "flt:"
 	}
 
 	return p
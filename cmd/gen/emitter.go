@@ -4,8 +4,11 @@ package main
 // - Outputs Systems(app *bevi.App)
 // - Deduplicates helpers (mappers, filters, resources, event readers/writers)
 // - Honors pointer-marked queries (*bevi.QueryN[T]) as WRITE intent; non-pointer queries as READ intent
-// - Applies explicit annotation overrides (Reads/Writes/ResReads/ResWrites)
-// - Adds event access (EventWriter/EventReader)
+// - Applies explicit annotation overrides (Reads/Writes/ResReads/ResWrites/RelReads/RelWrites)
+// - Adds relation access from //bevi:filter "~Type" tokens
+// - Adds event access (EventWriter/EventReader, RequestWriter/RequestReader); each system gets its own reader cursor
+// - Allocates one Commands buffer per system and marks it for sync points
+// - Passes one-shot input (bevi.In[T]) from the system context
 // - Preserves original function parameter order
 
 import (
@@ -15,6 +18,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -89,32 +93,27 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 			case ParamECSMap:
 				_ = ensureHelper(p.Kind, p.HelperKey, p.ElemTypes)
 			case ParamECSQuery, ParamECSFilter:
-				// Build a helper key augmented with per-parameter filter options to avoid duplicates.
-				key := p.HelperKey
-				if len(p.FilterOpts.With) > 0 || len(p.FilterOpts.Without) > 0 || p.FilterOpts.Exclusive || p.FilterOpts.Register {
-					var parts []string
-					parts = append(parts, key)
-					if len(p.FilterOpts.With) > 0 {
-						parts = append(parts, "with:"+strings.Join(p.FilterOpts.With, ","))
-					}
-					if len(p.FilterOpts.Without) > 0 {
-						parts = append(parts, "without:"+strings.Join(p.FilterOpts.Without, ","))
-					}
-					if p.FilterOpts.Exclusive {
-						parts = append(parts, "exclusive")
-					}
-					if p.FilterOpts.Register {
-						parts = append(parts, "register")
-					}
-					key = strings.Join(parts, "|")
-				}
+				// Build a per-system helper key augmented with the parameter's filter options.
+				key := filterHelperKey(sys, p)
 				_ = ensureHelper(p.Kind, key, p.ElemTypes)
 			case ParamECSResource:
 				_ = ensureHelper(p.Kind, p.HelperKey, p.ElemTypes)
 			case ParamEventWriter:
 				_ = ensureHelper(p.Kind, p.HelperKey, p.ElemTypes)
-			case ParamEventReader:
+			case ParamEventReader, ParamRequestReader:
+				key := readerKey(sys, p)
+				_ = ensureHelper(p.Kind, key, p.ElemTypes)
+				for i := range helpers {
+					if helpers[i].key == key {
+						helpers[i].reader = p.ReaderOpts
+					}
+				}
+			case ParamRequestWriter:
 				_ = ensureHelper(p.Kind, p.HelperKey, p.ElemTypes)
+			case ParamRemovedComponents:
+				_ = ensureHelper(p.Kind, p.HelperKey+"@"+sys.SystemName, p.ElemTypes)
+			case ParamCommands:
+				_ = ensureHelper(p.Kind, commandsKey(sys), nil)
 			}
 		}
 	}
@@ -143,6 +142,16 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 					required[al] = true
 				}
 			}
+			for _, t := range slices.Concat(p.FilterOpts.Relations, p.FilterOpts.Added, p.FilterOpts.Changed) {
+				if al := aliasFromTypeName(t); al != "" {
+					required[al] = true
+				}
+			}
+			for _, t := range slices.Concat(p.CmdWrites, p.CmdResWrites) {
+				if al := aliasFromTypeName(t); al != "" {
+					required[al] = true
+				}
+			}
 		}
 		// Also include aliases referenced by explicit access annotations.
 		for _, t := range sys.CompReads {
@@ -165,6 +174,11 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 				required[al] = true
 			}
 		}
+		for _, t := range append(slices.Clone(sys.RelReads), sys.RelWrites...) {
+			if al := aliasFromTypeName(t); al != "" {
+				required[al] = true
+			}
+		}
 	}
 	for _, sys := range pkg.SysSpecs {
 		for alias, ip := range sys.ExtraImports {
@@ -217,20 +231,32 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 			}
 			w("\t%s := bevi.NewMap%d[%s](app)\n", name, len(h.typs), gname)
 		case ParamECSQuery, ParamECSFilter:
-			// bevi.NewFilterN[T...](app) with chained options parsed from helper key
-			gname, err := genericTypeList(h.typs)
-			if err != nil {
-				return nil, err
+			// bevi.NewFilterN[T...](app) with chained options parsed from helper key;
+			// bevi.NewFilter0(app) for relation-only or filter-only queries.
+			if len(h.typs) == 0 {
+				w("\t%s := bevi.NewFilter0(app)\n", name)
+			} else {
+				gname, err := genericTypeList(h.typs)
+				if err != nil {
+					return nil, err
+				}
+				w("\t%s := bevi.NewFilter%d[%s](app)\n", name, len(h.typs), gname)
 			}
-			w("\t%s := bevi.NewFilter%d[%s](app)\n", name, len(h.typs), gname)
-			// Parse options from key: "...|with:a,b|without:c|exclusive|register"
+			// Parse options from key: "...|with:a,b|without:c|rel:d|added:e|changed:f|exclusive|register".
+			// Relation components are required like "with" types; their targets are
+			// passed at query time via bevi.Rel.
 			{
-				parts := strings.Split(h.key, "|")
+				key, _, _ := strings.Cut(h.key, "@")
+				parts := strings.Split(key, "|")
 				withs := []string{}
 				withouts := []string{}
+				var added, changed []string
 				excl := false
 				reg := false
 				for _, seg := range parts[1:] {
+					if after, ok := strings.CutPrefix(seg, "rel:"); ok {
+						seg = "with:" + after
+					}
 					if after, ok := strings.CutPrefix(seg, "with:"); ok {
 						items := strings.SplitSeq(after, ",")
 						for it := range items {
@@ -247,6 +273,10 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 								withouts = append(withouts, it)
 							}
 						}
+					} else if after, ok := strings.CutPrefix(seg, "added:"); ok {
+						added = append(added, strings.Split(after, ",")...)
+					} else if after, ok := strings.CutPrefix(seg, "changed:"); ok {
+						changed = append(changed, strings.Split(after, ",")...)
 					} else if seg == "exclusive" {
 						excl = true
 					} else if seg == "register" {
@@ -273,6 +303,22 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 					}
 					w(")\n")
 				}
+				for _, m := range []struct {
+					method string
+					typs   []string
+				}{{"Added", added}, {"Changed", changed}} {
+					if len(m.typs) == 0 {
+						continue
+					}
+					w("\t%s = %s.%s(", name, name, m.method)
+					for i, t := range m.typs {
+						if i > 0 {
+							w(", ")
+						}
+						w("bevi.C[%s]()", t)
+					}
+					w(")\n")
+				}
 				if excl {
 					w("\t%s = %s.Exclusive()\n", name, name)
 				}
@@ -297,7 +343,36 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 			if len(h.typs) != 1 {
 				return nil, fmt.Errorf("event reader expects 1 type param, got %v", h.typs)
 			}
-			w("\t%s := bevi.ReaderFor[%s](app.Events())\n", name, h.typs[0])
+			if opts := readerOptionsExpr(h.reader); opts != "" {
+				w("\t%s := bevi.ReaderWith[%s](app.Events(), %s)\n", name, h.typs[0], opts)
+			} else {
+				w("\t%s := bevi.ReaderFor[%s](app.Events())\n", name, h.typs[0])
+			}
+		case ParamRequestWriter:
+			// bevi.RequestWriterFor[T, R](app.Events())
+			if len(h.typs) != 2 {
+				return nil, fmt.Errorf("request writer expects 2 type params, got %v", h.typs)
+			}
+			w("\t%s := bevi.RequestWriterFor[%s, %s](app.Events())\n", name, h.typs[0], h.typs[1])
+		case ParamRequestReader:
+			// bevi.RequestReaderFor[T, R](app.Events()), one per system
+			if len(h.typs) != 2 {
+				return nil, fmt.Errorf("request reader expects 2 type params, got %v", h.typs)
+			}
+			if opts := readerOptionsExpr(h.reader); opts != "" {
+				w("\t%s := bevi.RequestReaderWith[%s, %s](app.Events(), %s)\n", name, h.typs[0], h.typs[1], opts)
+			} else {
+				w("\t%s := bevi.RequestReaderFor[%s, %s](app.Events())\n", name, h.typs[0], h.typs[1])
+			}
+		case ParamRemovedComponents:
+			// bevi.NewRemovedComponents[T](app), one per system
+			if len(h.typs) != 1 {
+				return nil, fmt.Errorf("removed components expects 1 type param, got %v", h.typs)
+			}
+			w("\t%s := bevi.NewRemovedComponents[%s](app)\n", name, h.typs[0])
+		case ParamCommands:
+			// bevi.NewCommands(app), one buffer per system
+			w("\t%s := bevi.NewCommands(app)\n", name)
 		default:
 			// ignore
 		}
@@ -311,12 +386,14 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 		// Build AccessMeta inference with priority:
 		// - Default: Query -> READ; pointer-marked Query (*bevi.QueryN[T]) -> WRITE
 		//            Map -> WRITE; Resource -> ResREAD; pointer-marked Resource (*bevi.Resource[T]) -> ResWRITE
-		// - Event access from params
+		// - Event access from params; pointer-marked readers (*bevi.EventReader[T]) -> EventMUTATE
 		// - Explicit annotation overrides applied after defaults (Reads removes Write)
 		compRead := map[string]bool{}
 		compWrite := map[string]bool{}
 		resRead := map[string]bool{}
 		resWrite := map[string]bool{}
+		relRead := map[string]bool{}
+		relWrite := map[string]bool{}
 		eventLines := []string{}
 		for _, p := range sys.Params {
 			switch p.Kind {
@@ -326,9 +403,48 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 				}
 			case ParamEventReader:
 				if len(p.ElemTypes) == 1 {
-					eventLines = append(eventLines, fmt.Sprintf("bevi.AccessEventRead[%s](&acc)", p.ElemTypes[0]))
+					// Pointer-marked readers may mutate events via ForEachMut
+					if p.Pointer {
+						eventLines = append(eventLines, fmt.Sprintf("bevi.AccessEventMutate[%s](&acc)", p.ElemTypes[0]))
+					} else {
+						eventLines = append(eventLines, fmt.Sprintf("bevi.AccessEventRead[%s](&acc)", p.ElemTypes[0]))
+					}
+					if pr := p.ReaderOpts.Priority; pr != "" && pr != "Normal" {
+						eventLines = append(eventLines, fmt.Sprintf("bevi.AccessEventPriority[%s](&acc, bevi.Priority%s)", p.ElemTypes[0], pr))
+					}
+				}
+			case ParamRequestWriter:
+				if len(p.ElemTypes) == 2 {
+					eventLines = append(eventLines, fmt.Sprintf("bevi.AccessRequestWrite[%s, %s](&acc)", p.ElemTypes[0], p.ElemTypes[1]))
+				}
+			case ParamRequestReader:
+				if len(p.ElemTypes) == 2 {
+					eventLines = append(eventLines, fmt.Sprintf("bevi.AccessRequestRead[%s, %s](&acc)", p.ElemTypes[0], p.ElemTypes[1]))
+					if pr := p.ReaderOpts.Priority; pr != "" && pr != "Normal" {
+						eventLines = append(eventLines, fmt.Sprintf("bevi.AccessRequestPriority[%s, %s](&acc, bevi.Priority%s)", p.ElemTypes[0], p.ElemTypes[1], pr))
+					}
+				}
+			case ParamCommands:
+				// Deferred operations are applied at a sync point after this system's
+				// batch; the types its recorded calls write are declared so the
+				// access reflects what the system changes.
+				if !slices.Contains(eventLines, "bevi.AccessCommands(&acc)") {
+					eventLines = append(eventLines, "bevi.AccessCommands(&acc)")
+				}
+				for _, t := range p.CmdWrites {
+					compWrite[t] = true
+				}
+				for _, t := range p.CmdResWrites {
+					resWrite[t] = true
 				}
 			case ParamECSQuery:
+				for _, t := range p.FilterOpts.Relations {
+					relRead[t] = true
+				}
+				// Change filters read the component's change ticks
+				for _, t := range slices.Concat(p.FilterOpts.Added, p.FilterOpts.Changed) {
+					compRead[t] = true
+				}
 				// Pointer-marked queries imply WRITE; non-pointer default to READ
 				if p.Pointer {
 					for _, t := range p.ElemTypes {
@@ -339,6 +455,17 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 						compRead[t] = true
 					}
 				}
+			case ParamECSFilter:
+				for _, t := range p.FilterOpts.Relations {
+					relRead[t] = true
+				}
+				for _, t := range slices.Concat(p.FilterOpts.Added, p.FilterOpts.Changed) {
+					compRead[t] = true
+				}
+			case ParamRemovedComponents:
+				for _, t := range p.ElemTypes {
+					compRead[t] = true
+				}
 			case ParamECSMap:
 				for _, t := range p.ElemTypes {
 					compWrite[t] = true
@@ -371,6 +498,12 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 		for _, t := range sys.ResWrites {
 			resWrite[t] = true
 		}
+		for _, t := range sys.RelReads {
+			relRead[t] = true
+		}
+		for _, t := range sys.RelWrites {
+			relWrite[t] = true
+		}
 		// Compose final lines; write dominates read
 		accessLines := make([]string, 0, len(eventLines)+len(compRead)+len(compWrite)+len(resRead)+len(resWrite))
 		accessLines = append(accessLines, eventLines...)
@@ -390,6 +523,21 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 				accessLines = append(accessLines, fmt.Sprintf("bevi.AccessResRead[%s](&acc)", t))
 			}
 		}
+		for t := range relWrite {
+			accessLines = append(accessLines, fmt.Sprintf("bevi.AccessRelWrite[%s](&acc)", t))
+		}
+		for t := range relRead {
+			if !relWrite[t] {
+				accessLines = append(accessLines, fmt.Sprintf("bevi.AccessRelRead[%s](&acc)", t))
+			}
+		}
+		with, without := archetypeFilter(sys)
+		for _, t := range with {
+			accessLines = append(accessLines, fmt.Sprintf("bevi.AccessWith[%s](&acc)", t))
+		}
+		for _, t := range without {
+			accessLines = append(accessLines, fmt.Sprintf("bevi.AccessWithout[%s](&acc)", t))
+		}
 		sort.Strings(accessLines)
 
 		// Meta
@@ -428,24 +576,7 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 				args = append(args, name)
 			case ParamECSQuery:
 				// Lookup helper name using an augmented key that includes per-param filter options.
-				key := p.HelperKey
-				if len(p.FilterOpts.With) > 0 || len(p.FilterOpts.Without) > 0 || p.FilterOpts.Exclusive || p.FilterOpts.Register {
-					var parts []string
-					parts = append(parts, key)
-					if len(p.FilterOpts.With) > 0 {
-						parts = append(parts, "with:"+strings.Join(p.FilterOpts.With, ","))
-					}
-					if len(p.FilterOpts.Without) > 0 {
-						parts = append(parts, "without:"+strings.Join(p.FilterOpts.Without, ","))
-					}
-					if p.FilterOpts.Exclusive {
-						parts = append(parts, "exclusive")
-					}
-					if p.FilterOpts.Register {
-						parts = append(parts, "register")
-					}
-					key = strings.Join(parts, "|")
-				}
+				key := filterHelperKey(sys, p)
 				name := findHelperName(helpers, key)
 				if name == "" {
 					return nil, fmt.Errorf("internal: missing query helper for %v", p.ElemTypes)
@@ -465,24 +596,7 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 				}
 			case ParamECSFilter:
 				// Lookup helper for filter param and pass it directly
-				key := p.HelperKey
-				if len(p.FilterOpts.With) > 0 || len(p.FilterOpts.Without) > 0 || p.FilterOpts.Exclusive || p.FilterOpts.Register {
-					var parts []string
-					parts = append(parts, key)
-					if len(p.FilterOpts.With) > 0 {
-						parts = append(parts, "with:"+strings.Join(p.FilterOpts.With, ","))
-					}
-					if len(p.FilterOpts.Without) > 0 {
-						parts = append(parts, "without:"+strings.Join(p.FilterOpts.Without, ","))
-					}
-					if p.FilterOpts.Exclusive {
-						parts = append(parts, "exclusive")
-					}
-					if p.FilterOpts.Register {
-						parts = append(parts, "register")
-					}
-					key = strings.Join(parts, "|")
-				}
+				key := filterHelperKey(sys, p)
 				name := findHelperName(helpers, key)
 				if name == "" {
 					return nil, fmt.Errorf("internal: missing filter helper for %v", p.ElemTypes)
@@ -506,11 +620,48 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 				}
 				args = append(args, name)
 			case ParamEventReader:
-				name := findHelperName(helpers, p.HelperKey)
+				name := findHelperName(helpers, readerKey(sys, p))
 				if name == "" {
 					return nil, fmt.Errorf("internal: missing event reader helper for %v", p.ElemTypes)
 				}
+				if p.Pointer {
+					args = append(args, "&"+name)
+				} else {
+					args = append(args, name)
+				}
+			case ParamRequestWriter:
+				name := findHelperName(helpers, p.HelperKey)
+				if name == "" {
+					return nil, fmt.Errorf("internal: missing request writer helper for %v", p.ElemTypes)
+				}
 				args = append(args, name)
+			case ParamRequestReader:
+				name := findHelperName(helpers, readerKey(sys, p))
+				if name == "" {
+					return nil, fmt.Errorf("internal: missing request reader helper for %v", p.ElemTypes)
+				}
+				args = append(args, name)
+			case ParamRemovedComponents:
+				name := findHelperName(helpers, p.HelperKey+"@"+sys.SystemName)
+				if name == "" {
+					return nil, fmt.Errorf("internal: missing removed components helper for %v", p.ElemTypes)
+				}
+				if p.Pointer {
+					args = append(args, name)
+				} else {
+					args = append(args, "*"+name)
+				}
+			case ParamCommands:
+				name := findHelperName(helpers, commandsKey(sys))
+				if name == "" {
+					return nil, fmt.Errorf("internal: missing commands helper for %s", sys.FuncName)
+				}
+				args = append(args, name)
+			case ParamInput:
+				if len(p.ElemTypes) != 1 {
+					return nil, fmt.Errorf("input expects 1 type param in %s, got %v", sys.FuncName, p.ElemTypes)
+				}
+				args = append(args, fmt.Sprintf("bevi.InputFrom[%s](ctx)", p.ElemTypes[0]))
 			default:
 				return nil, fmt.Errorf("unsupported parameter in %s: %s", sys.FuncName, p.TypeExpr)
 			}
@@ -533,6 +684,43 @@ func emitPackage(ctx *Context, pkg *Package) ([]byte, error) {
 	return src, nil
 }
 
+// archetypeFilter computes the With/Without constraints shared by every
+// component access of sys, letting the scheduler run systems with disjoint
+// filters in parallel. Each query or filter parameter requires its component
+// types plus its //bevi:filter "+", "~", Added and Changed types and excludes
+// its "-" types; the system-wide constraint is the intersection across all of
+// them. Systems that may touch arbitrary entities (maps, the world, commands
+// that write components or explicit Reads/Writes overrides, which need not go
+// through a query) get no constraint.
+func archetypeFilter(sys *System) (with, without []string) {
+	if len(sys.CompReads) > 0 || len(sys.CompWrites) > 0 {
+		return nil, nil
+	}
+	first := true
+	for _, p := range sys.Params {
+		switch p.Kind {
+		case ParamECSMap, ParamWorld:
+			return nil, nil
+		case ParamCommands:
+			if len(p.CmdWrites) > 0 {
+				return nil, nil
+			}
+		case ParamECSQuery, ParamECSFilter:
+			req := append(append([]string(nil), p.ElemTypes...), p.FilterOpts.With...)
+			req = slices.Concat(req, p.FilterOpts.Relations, p.FilterOpts.Added, p.FilterOpts.Changed)
+			if first {
+				with = sortUnique(req)
+				without = sortUnique(p.FilterOpts.Without)
+				first = false
+				continue
+			}
+			with = intersectStrings(with, req)
+			without = intersectStrings(without, p.FilterOpts.Without)
+		}
+	}
+	return with, without
+}
+
 func helperName(i int, h genHelper) string {
 	// Stable helper name by index and kind prefix
 	prefix := "h"
@@ -547,12 +735,81 @@ func helperName(i int, h genHelper) string {
 		prefix = "ew"
 	case ParamEventReader:
 		prefix = "er"
+	case ParamRequestWriter:
+		prefix = "rw"
+	case ParamRequestReader:
+		prefix = "rr"
+	case ParamRemovedComponents:
+		prefix = "rm"
+	case ParamCommands:
+		prefix = "cmd"
 	default:
 		prefix = "h"
 	}
 	return fmt.Sprintf("_%s_%d", prefix, i)
 }
 
+// commandsKey returns the helper key for a system's Commands buffer. Buffers are
+// never shared so each system's commands are applied in a deterministic order.
+func commandsKey(sys *System) string {
+	return "cmd:" + sys.SystemName
+}
+
+// readerKey returns the helper key for an event reader parameter. Readers are
+// never shared between systems, since each one owns its event cursor.
+func readerKey(sys *System, p Param) string {
+	return p.HelperKey + "@" + sys.SystemName
+}
+
+// readerOptionsExpr renders a bevi.ReaderOptions literal for o, or "" if o is
+// the default.
+func readerOptionsExpr(o ReaderOptions) string {
+	var fields []string
+	if o.Priority != "" && o.Priority != "Normal" {
+		fields = append(fields, "Priority: bevi.Priority"+o.Priority)
+	}
+	if o.IgnoreCancelled {
+		fields = append(fields, "IgnoreCancelled: true")
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return "bevi.ReaderOptions{" + strings.Join(fields, ", ") + "}"
+}
+
+// filterHelperKey returns the helper key for a query or filter parameter,
+// augmented with its //bevi:filter options and the system name:
+// "<key>|with:a,b|without:c|rel:d|added:e|changed:f|exclusive|register@<system>".
+// Every filter tracks the changes since its previous query, for Added and
+// Changed options as well as Ref.Added and Ref.Changed, so filters are never
+// shared between systems.
+func filterHelperKey(sys *System, p Param) string {
+	parts := []string{p.HelperKey}
+	fo := p.FilterOpts
+	if len(fo.With) > 0 {
+		parts = append(parts, "with:"+strings.Join(fo.With, ","))
+	}
+	if len(fo.Without) > 0 {
+		parts = append(parts, "without:"+strings.Join(fo.Without, ","))
+	}
+	if len(fo.Relations) > 0 {
+		parts = append(parts, "rel:"+strings.Join(fo.Relations, ","))
+	}
+	if len(fo.Added) > 0 {
+		parts = append(parts, "added:"+strings.Join(fo.Added, ","))
+	}
+	if len(fo.Changed) > 0 {
+		parts = append(parts, "changed:"+strings.Join(fo.Changed, ","))
+	}
+	if fo.Exclusive {
+		parts = append(parts, "exclusive")
+	}
+	if fo.Register {
+		parts = append(parts, "register")
+	}
+	return strings.Join(parts, "|") + "@" + sys.SystemName
+}
+
 func findHelperName(hs []genHelper, key string) string {
 	for i, h := range hs {
 		if h.key == key {
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// analyze runs the default analyzers over a single file with source src,
+// which must declare at least one system.
+func analyze(t *testing.T, src string) (*Context, *Package) {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "systems.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	pkg := &Package{Dir: ".", Name: f.Name.Name, FileSet: fset, Files: []*GoFile{{Path: "systems.go", Ast: f}}}
+	ctx := &Context{Packages: []*Package{pkg}, Logger: func(string, ...any) {}}
+	for _, a := range DefaultAnalyzers() {
+		if err := a.Run(ctx); err != nil {
+			t.Fatalf("analyzer %s: %v", a.Name(), err)
+		}
+	}
+	if len(pkg.SysSpecs) == 0 {
+		t.Fatal("expected a system")
+	}
+	return ctx, pkg
+}
+
+const genHeader = `package p
+
+import "github.com/oriumgames/bevi"
+
+type ChildOf struct{ bevi.Relation }
+type Pos struct{}
+type Vel struct{}
+`
+
+// Test that the analyzer binds "~Type" filter tokens, RelReads/RelWrites
+// annotations and Query0/Filter0 parameters.
+func TestAnalyzeRelations(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		relations []string // FilterOpts.Relations of the first parameter
+		relReads  []string
+		relWrites []string
+		keys      []string // HelperKey of each parameter
+	}{
+		{
+			name: "relation token",
+			src: `//bevi:system Update
+//bevi:filter q ~ChildOf
+func S(q bevi.Query1[Pos]) {}`,
+			relations: []string{"ChildOf"},
+			keys:      []string{"query:Pos"},
+		},
+		{
+			name: "positional relation token",
+			src: `//bevi:system Update
+//bevi:filter Q0 ~ChildOf +Vel
+func S(q bevi.Query1[Pos]) {}`,
+			relations: []string{"ChildOf"},
+			keys:      []string{"query:Pos"},
+		},
+		{
+			name: "relation annotations",
+			src: `//bevi:system Update RelReads={ChildOf} RelWrites={Pos}
+func S(q bevi.Query1[Vel]) {}`,
+			relReads:  []string{"ChildOf"},
+			relWrites: []string{"Pos"},
+			keys:      []string{"query:Vel"},
+		},
+		{
+			name: "query0 and filter0",
+			src: `//bevi:system Update
+//bevi:filter q ~ChildOf
+func S(q bevi.Query0, f bevi.Filter0) {}`,
+			relations: []string{"ChildOf"},
+			keys:      []string{"query:", "flt:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, pkg := analyze(t, genHeader+tt.src)
+			sys := pkg.SysSpecs[0]
+			if len(sys.Params) != len(tt.keys) {
+				t.Fatalf("expected %d params, got %d", len(tt.keys), len(sys.Params))
+			}
+			for i, p := range sys.Params {
+				if p.HelperKey != tt.keys[i] {
+					t.Errorf("param %d: HelperKey = %q, want %q", i, p.HelperKey, tt.keys[i])
+				}
+			}
+			if got := sys.Params[0].FilterOpts.Relations; !slices.Equal(got, tt.relations) {
+				t.Errorf("Relations = %v, want %v", got, tt.relations)
+			}
+			if !slices.Equal(sys.RelReads, tt.relReads) {
+				t.Errorf("RelReads = %v, want %v", sys.RelReads, tt.relReads)
+			}
+			if !slices.Equal(sys.RelWrites, tt.relWrites) {
+				t.Errorf("RelWrites = %v, want %v", sys.RelWrites, tt.relWrites)
+			}
+		})
+	}
+}
+
+// Test that the emitter turns relation filters into required components and
+// relation access, and constructs Query0/Filter0 parameters with NewFilter0.
+func TestEmitRelations(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+		omit []string
+	}{
+		{
+			name: "relation token",
+			src: `//bevi:system Update
+//bevi:filter q ~ChildOf
+func S(q bevi.Query1[Pos]) {}`,
+			want: []string{
+				"bevi.NewFilter1[Pos](app)",
+				".With(bevi.C[ChildOf]())",
+				"bevi.AccessRelRead[ChildOf](&acc)",
+				"bevi.AccessRead[Pos](&acc)",
+			},
+		},
+		{
+			name: "relation write dominates read",
+			src: `//bevi:system Update RelWrites={ChildOf}
+//bevi:filter q ~ChildOf
+func S(q bevi.Query1[Pos]) {}`,
+			want: []string{"bevi.AccessRelWrite[ChildOf](&acc)"},
+			omit: []string{"bevi.AccessRelRead[ChildOf](&acc)"},
+		},
+		{
+			name: "relation read annotation",
+			src: `//bevi:system Update RelReads={ChildOf}
+func S(q bevi.Query1[Pos]) {}`,
+			want: []string{"bevi.AccessRelRead[ChildOf](&acc)"},
+		},
+		{
+			name: "query0 and filter0",
+			src: `//bevi:system Update
+//bevi:filter q ~ChildOf +Pos
+func S(q bevi.Query0, f bevi.Filter0) {}`,
+			want: []string{
+				"_flt_0 := bevi.NewFilter0(app)",
+				"_flt_0 = _flt_0.With(bevi.C[Pos](), bevi.C[ChildOf]())",
+				"_flt_1 := bevi.NewFilter0(app)",
+				"_q0 := _flt_0.Query()",
+				"S(_q0, _flt_1)",
+				"bevi.AccessRelRead[ChildOf](&acc)",
+			},
+			omit: []string{"NewFilter1", "bevi.AccessRead["},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, pkg := analyze(t, genHeader+tt.src)
+			out, err := emitPackage(ctx, pkg)
+			if err != nil {
+				t.Fatalf("emit: %v\n%s", err, out)
+			}
+			src := string(out)
+			for _, s := range tt.want {
+				if !strings.Contains(src, s) {
+					t.Errorf("missing %q in:\n%s", s, src)
+				}
+			}
+			for _, s := range tt.omit {
+				if strings.Contains(src, s) {
+					t.Errorf("unexpected %q in:\n%s", s, src)
+				}
+			}
+		})
+	}
+}
+
+// Test that the emitter declares the types written by recorded Commands as
+// component and resource writes.
+func TestEmitCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+		omit []string
+	}{
+		{
+			name: "spawn and add",
+			src: `//bevi:system Update
+func S(cmd bevi.Commands, q bevi.Query1[Pos]) {
+	cmd.Spawn(&Pos{}, Vel{})
+	cmd.Add(bevi.Entity{}, new(Vel))
+}`,
+			want: []string{
+				"bevi.AccessCommands(&acc)",
+				"bevi.AccessWrite[Pos](&acc)",
+				"bevi.AccessWrite[Vel](&acc)",
+			},
+			omit: []string{"bevi.AccessRead[Pos](&acc)", "bevi.AccessWith["},
+		},
+		{
+			name: "remove and resource",
+			src: `type Config struct{}
+
+//bevi:system Update
+func S(cmd bevi.Commands) {
+	cmd.Remove(bevi.Entity{}, bevi.C[Vel]())
+	cmd.InsertResource(&Config{})
+}`,
+			want: []string{
+				"bevi.AccessWrite[Vel](&acc)",
+				"bevi.AccessResWrite[Config](&acc)",
+			},
+			omit: []string{"bevi.AccessWrite[Config](&acc)"},
+		},
+		{
+			name: "untyped arguments",
+			src: `//bevi:system Update
+func S(cmd bevi.Commands, q bevi.Query1[Pos]) {
+	var v any = &Vel{}
+	cmd.Spawn(v)
+	cmd.Despawn(bevi.Entity{})
+}`,
+			want: []string{"bevi.AccessCommands(&acc)", "bevi.AccessWith[Pos](&acc)"},
+			omit: []string{"bevi.AccessWrite["},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, pkg := analyze(t, genHeader+tt.src)
+			out, err := emitPackage(ctx, pkg)
+			if err != nil {
+				t.Fatalf("emit: %v\n%s", err, out)
+			}
+			src := string(out)
+			for _, s := range tt.want {
+				if !strings.Contains(src, s) {
+					t.Errorf("missing %q in:\n%s", s, src)
+				}
+			}
+			for _, s := range tt.omit {
+				if strings.Contains(src, s) {
+					t.Errorf("unexpected %q in:\n%s", s, src)
+				}
+			}
+		})
+	}
+}
+
+// Test that systems declaring the same query get their own filter helpers,
+// since each filter tracks changes since its own previous query.
+func TestEmitFilterPerSystem(t *testing.T) {
+	ctx, pkg := analyze(t, genHeader+`//bevi:system Update
+func Fast(q bevi.Query1[Pos]) {}
+
+//bevi:system Update Every=1s
+func Slow(q bevi.Query1[Pos]) {}
+
+//bevi:system Update
+//bevi:filter q +Vel
+func Other(q bevi.Query1[Pos], f *bevi.Filter1[Pos]) {}`)
+	out, err := emitPackage(ctx, pkg)
+	if err != nil {
+		t.Fatalf("emit: %v\n%s", err, out)
+	}
+	src := string(out)
+	if n := strings.Count(src, "bevi.NewFilter1[Pos](app)"); n != 4 {
+		t.Errorf("expected 4 filter helpers, got %d in:\n%s", n, src)
+	}
+	for _, s := range []string{"_q0 := _flt_0.Query()", "_q0 := _flt_1.Query()", "Other(_q0, _flt_3)"} {
+		if !strings.Contains(src, s) {
+			t.Errorf("missing %q in:\n%s", s, src)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -24,6 +25,7 @@ import (
 // Fields:
 //   - Stage/Every/Set/After/Before: derived from annotation
 //   - CompReads/CompWrites/ResReads/ResWrites: explicit access overrides from annotation
+//   - RelReads/RelWrites: relation components whose targets are read or changed
 //   - Params: inferred from function parameters
 //   - SystemName: registration name (defaults to function name)
 type System struct {
@@ -42,6 +44,8 @@ type System struct {
 	CompWrites []string       // optional component writes override
 	ResReads   []string       // optional resource reads override
 	ResWrites  []string       // optional resource writes override
+	RelReads   []string       // optional relation target reads
+	RelWrites  []string       // optional relation target writes
 
 	// Parameters inferred
 	Params []Param
@@ -60,6 +64,10 @@ type System struct {
 	// Per-parameter filter options gathered from //bevi:filter lines.
 	// Key is parameter name (preferred) or synthetic like "Q0", "F0".
 	FilterByParam map[string]FilterOptions
+
+	// Per-parameter reader options gathered from //bevi:reader lines.
+	// Key is parameter name (preferred) or synthetic like "R0".
+	ReaderByParam map[string]ReaderOptions
 }
 
 // ParamKind describes the high-level category for an injected parameter.
@@ -75,6 +83,11 @@ const (
 	ParamEventWriter
 	ParamEventReader
 	ParamECSFilter
+	ParamCommands
+	ParamInput
+	ParamRequestWriter
+	ParamRequestReader
+	ParamRemovedComponents
 )
 
 // String returns a short label for the parameter kind (debugging).
@@ -96,6 +109,16 @@ func (k ParamKind) String() string {
 		return "EventReader"
 	case ParamECSFilter:
 		return "ECSFilter"
+	case ParamCommands:
+		return "Commands"
+	case ParamInput:
+		return "Input"
+	case ParamRequestWriter:
+		return "RequestWriter"
+	case ParamRequestReader:
+		return "RequestReader"
+	case ParamRemovedComponents:
+		return "RemovedComponents"
 	default:
 		return "Unknown"
 	}
@@ -111,6 +134,9 @@ func (k ParamKind) String() string {
 //   - Pointer: true if parameter type is a pointer to the kind (e.g., *bevi.QueryN[T])
 //     This can be used to drive conventions like pointer-marked queries imply write.
 //   - FilterOpts: merged filter options for this parameter (from //bevi:filter)
+//   - ReaderOpts: reader options for event/request readers (from //bevi:reader)
+//   - CmdWrites/CmdResWrites: component and resource types written by calls
+//     recorded on a bevi.Commands parameter in the system body
 type Param struct {
 	Name       string
 	Kind       ParamKind
@@ -119,24 +145,41 @@ type Param struct {
 	HelperKey  string
 	Pointer    bool
 	FilterOpts FilterOptions
+	ReaderOpts ReaderOptions
+
+	CmdWrites    []string
+	CmdResWrites []string
 }
 
 // genHelper is an internal declaration used by the emitter to define
 // package-level helpers (mappers, filters, resources, readers/writers) exactly once.
 type genHelper struct {
-	key  string
-	kind ParamKind
-	typs []string
+	key    string
+	kind   ParamKind
+	typs   []string
+	reader ReaderOptions
 }
 
 // FilterOptions captures advanced Ark ECS Filter chain options for a target parameter.
 type FilterOptions struct {
 	With      []string // component type names, possibly qualified (e.g., pkg.Type)
 	Without   []string // component type names, possibly qualified
+	Relations []string // relation component types whose targets are queried
+	Added     []string // component types that must have been added since the last run
+	Changed   []string // component types that must have been added or changed since the last run
 	Exclusive bool
 	Register  bool
 }
 
+// ReaderOptions captures the //bevi:reader options of an event or request reader.
+type ReaderOptions struct {
+	Priority        string // Lowest, Low, Normal, High, Highest or Monitor; empty means Normal
+	IgnoreCancelled bool
+}
+
+// readerPriorities lists the valid //bevi:reader priority names.
+var readerPriorities = []string{"Lowest", "Low", "Normal", "High", "Highest", "Monitor"}
+
 // -----------------------------
 // Generic string parsing helpers
 // -----------------------------
@@ -360,6 +403,17 @@ func sortUnique(in []string) []string {
 	return out
 }
 
+// intersectStrings returns the sorted elements of a that are also in b.
+func intersectStrings(a, b []string) []string {
+	var out []string
+	for _, s := range a {
+		if slices.Contains(b, s) {
+			out = append(out, s)
+		}
+	}
+	return sortUnique(out)
+}
+
 // -----------------------------
 // Type normalization helpers
 // -----------------------------
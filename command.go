@@ -0,0 +1,287 @@
+package bevi
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/mlange-42/ark/ecs"
+)
+
+// Commands records structural world changes (spawn, despawn, component
+// add/remove, resource insertion) so that systems running in parallel never
+// touch Ark's archetype storage concurrently. Recorded commands are applied
+// exclusively at the next sync point:
+//
+//   - after every batch containing a system that declares AccessCommands,
+//   - at explicit sync points registered with App.AddSyncPoint,
+//   - at the end of every stage.
+//
+// Commands is safe for concurrent use. A zero-value Commands drops everything.
+type Commands struct {
+	q   *commandQueue
+	app *App
+}
+
+// NewCommands allocates a command buffer owned by the App. Buffers are applied
+// in allocation order, and commands within a buffer in recording order.
+func NewCommands(app *App) Commands {
+	return Commands{q: app.cmds.newQueue(), app: app}
+}
+
+// Spawn creates a new entity with the given component values. Components may
+// be passed by value or by pointer; values are copied at apply time.
+func (c Commands) Spawn(comps ...any) {
+	c.SpawnFn(nil, comps...)
+}
+
+// SpawnFn is like Spawn but invokes fn with the created entity once the
+// command is applied. fn runs during the sync point with exclusive world access.
+func (c Commands) SpawnFn(fn func(w *World, e Entity), comps ...any) {
+	obs := c.observers()
+	c.push(func(w *World) {
+		ids, vals := componentIDs(w, comps)
+		var e Entity
+		obs.structural(func() { e = w.Unsafe().NewEntity(ids...) })
+		setComponents(w, e, ids, vals)
+		if obs.hooked() {
+			obs.applied(w, e, valueTypes(vals), nil)
+		}
+		if fn != nil && w.Alive(e) {
+			fn(w, e)
+		}
+	})
+}
+
+// Despawn removes the entity. Dead entities are ignored at apply time.
+func (c Commands) Despawn(e Entity) {
+	c.push(func(w *World) {
+		if w.Alive(e) {
+			w.RemoveEntity(e)
+		}
+	})
+}
+
+// Add inserts the given component values on the entity. Components the entity
+// already has are overwritten in place; missing components are added.
+func (c Commands) Add(e Entity, comps ...any) {
+	obs := c.observers()
+	c.push(func(w *World) {
+		if !w.Alive(e) {
+			return
+		}
+		ids, vals := componentIDs(w, comps)
+		u := w.Unsafe()
+		var missing []ecs.ID
+		var added, replaced []reflect.Type
+		for i, id := range ids {
+			if !u.Has(e, id) {
+				missing = append(missing, id)
+				added = append(added, vals[i].Type())
+			} else {
+				replaced = append(replaced, vals[i].Type())
+			}
+		}
+		if len(missing) > 0 {
+			obs.structural(func() { u.Add(e, missing...) })
+		}
+		setComponents(w, e, ids, vals)
+		if c.app != nil {
+			c.app.changes.replaced(e, replaced)
+		}
+		obs.applied(w, e, added, replaced)
+	})
+}
+
+// Remove removes the given components from the entity. Components the entity
+// does not have are ignored.
+func (c Commands) Remove(e Entity, comps ...Component) {
+	c.push(func(w *World) {
+		if !w.Alive(e) {
+			return
+		}
+		u := w.Unsafe()
+		var ids []ecs.ID
+		for _, comp := range comps {
+			id := ecs.TypeID(w, comp.Type())
+			if u.Has(e, id) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > 0 {
+			u.Remove(e, ids...)
+		}
+	})
+}
+
+// InsertResource adds res as a world resource, replacing any existing resource
+// of the same type. res should be a pointer; values are copied into a new one.
+func (c Commands) InsertResource(res any) {
+	c.push(func(w *World) {
+		v := reflect.ValueOf(res)
+		if v.Kind() != reflect.Ptr {
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			v = p
+		}
+		id := ecs.ResourceTypeID(w, v.Type().Elem())
+		resources := w.Resources()
+		if resources.Has(id) {
+			resources.Remove(id)
+		}
+		resources.Add(id, v.Interface())
+	})
+}
+
+// Run records an arbitrary function to run with exclusive world access at the
+// next sync point.
+func (c Commands) Run(fn func(w *World)) {
+	c.push(fn)
+}
+
+// RunSystem runs the one-shot system registered under id with the given input
+// at the next sync point. Errors, including an unknown id, are reported to
+// diagnostics through SystemEnd, as there is no caller to return them to.
+func (c Commands) RunSystem(id string, input any) {
+	app := c.app
+	c.push(func(*World) {
+		_ = app.runOneShot(id, input)
+	})
+}
+
+// Trigger runs the observers registered with Observe for ev's type at the
+// next sync point.
+func (c Commands) Trigger(ev any) {
+	c.TriggerFor(Entity{}, ev)
+}
+
+// TriggerFor runs the observers for ev's type targeting the given entity at
+// the next sync point, propagating it if configured with Propagate. Events
+// for entities despawned in the meantime are dropped.
+func (c Commands) TriggerFor(target Entity, ev any) {
+	app := c.app
+	c.push(func(w *World) {
+		if !target.IsZero() && !w.Alive(target) {
+			return
+		}
+		app.observers.trigger(app, target, ev)
+	})
+}
+
+// observers returns the App's observers, or an empty set for a zero Commands.
+func (c Commands) observers() *observers {
+	if c.app == nil {
+		return &observers{}
+	}
+	return &c.app.observers
+}
+
+func (c Commands) push(fn func(w *World)) {
+	if c.q == nil {
+		return
+	}
+	c.q.mu.Lock()
+	c.q.cmds = append(c.q.cmds, fn)
+	c.q.mu.Unlock()
+}
+
+// commandQueue is a single Commands buffer.
+type commandQueue struct {
+	mu    sync.Mutex
+	cmds  []func(*World)
+	spare []func(*World)
+}
+
+// take swaps out the recorded commands, reusing the previous backing array.
+func (q *commandQueue) take() []func(*World) {
+	q.mu.Lock()
+	cmds := q.cmds
+	q.cmds, q.spare = q.spare[:0], nil
+	q.mu.Unlock()
+	return cmds
+}
+
+// recycle hands an applied command slice back for reuse by take.
+func (q *commandQueue) recycle(cmds []func(*World)) {
+	clear(cmds)
+	q.mu.Lock()
+	if q.spare == nil {
+		q.spare = cmds[:0]
+	}
+	q.mu.Unlock()
+}
+
+// commandQueues owns all buffers allocated for an App.
+type commandQueues struct {
+	mu     sync.Mutex
+	queues []*commandQueue
+}
+
+func (cs *commandQueues) newQueue() *commandQueue {
+	q := &commandQueue{}
+	cs.mu.Lock()
+	cs.queues = append(cs.queues, q)
+	cs.mu.Unlock()
+	return q
+}
+
+// apply runs all recorded commands in deterministic order. Commands recorded
+// while applying (e.g. from SpawnFn callbacks) are applied in the same pass.
+func (cs *commandQueues) apply(w *World) {
+	cs.mu.Lock()
+	queues := cs.queues
+	cs.mu.Unlock()
+
+	for pending := true; pending; {
+		pending = false
+		for _, q := range queues {
+			cmds := q.take()
+			if len(cmds) > 0 {
+				pending = true
+				for _, fn := range cmds {
+					fn(w)
+				}
+			}
+			q.recycle(cmds)
+		}
+	}
+}
+
+// componentIDs resolves component IDs for the given values, dereferencing pointers.
+func componentIDs(w *World, comps []any) ([]ecs.ID, []reflect.Value) {
+	ids := make([]ecs.ID, len(comps))
+	vals := make([]reflect.Value, len(comps))
+	for i, comp := range comps {
+		v := reflect.ValueOf(comp)
+		if !v.IsValid() {
+			panic(fmt.Sprintf("bevi: nil component at index %d", i))
+		}
+		for v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		ids[i] = ecs.TypeID(w, v.Type())
+		vals[i] = v
+	}
+	return ids, vals
+}
+
+// valueTypes returns the types of the given component values.
+func valueTypes(vals []reflect.Value) []reflect.Type {
+	types := make([]reflect.Type, len(vals))
+	for i, v := range vals {
+		types[i] = v.Type()
+	}
+	return types
+}
+
+// setComponents copies vals into the entity's component storage.
+func setComponents(w *World, e Entity, ids []ecs.ID, vals []reflect.Value) {
+	u := w.Unsafe()
+	for i, id := range ids {
+		if vals[i].Type().Size() == 0 {
+			continue
+		}
+		dst := reflect.NewAt(vals[i].Type(), u.Get(e, id)).Elem()
+		dst.Set(vals[i])
+	}
+}
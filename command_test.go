@@ -0,0 +1,111 @@
+package bevi_test
+
+import (
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/oriumgames/bevi"
+)
+
+type armor struct{ AC int }
+
+type difficulty struct{ Level int }
+
+// Test that Spawn, Add and Remove change the world only once the buffer is
+// applied at a sync point.
+func TestCommandsComponents(t *testing.T) {
+	app := newTestApp()
+	w := app.World()
+	hp := ecs.NewMap[health](w)
+	ac := ecs.NewMap[armor](w)
+	cmd := app.Commands()
+
+	var e bevi.Entity
+	cmd.SpawnFn(func(_ *bevi.World, spawned bevi.Entity) { e = spawned }, &health{HP: 5})
+	if e != (bevi.Entity{}) {
+		t.Fatal("expected Spawn to be deferred until the sync point")
+	}
+	flush(t, app)
+	if !w.Alive(e) || hp.Get(e).HP != 5 {
+		t.Fatalf("expected a spawned entity with health 5, got %v", e)
+	}
+
+	cmd.Add(e, health{HP: 7}, &armor{AC: 2})
+	if ac.Has(e) {
+		t.Fatal("expected Add to be deferred until the sync point")
+	}
+	flush(t, app)
+	if hp.Get(e).HP != 7 {
+		t.Fatalf("expected Add to overwrite health, got %d", hp.Get(e).HP)
+	}
+	if !ac.Has(e) || ac.Get(e).AC != 2 {
+		t.Fatal("expected Add to insert the missing armor")
+	}
+
+	cmd.Remove(e, bevi.C[armor]())
+	flush(t, app)
+	if ac.Has(e) || !hp.Has(e) {
+		t.Fatal("expected Remove to drop armor and keep health")
+	}
+
+	// Spawn without a callback creates an entity with the given values.
+	cmd.Spawn(armor{AC: 9})
+	flush(t, app)
+	n := 0
+	q := ecs.NewFilter1[armor](w).Query()
+	for q.Next() {
+		if q.Get().AC != 9 {
+			t.Errorf("expected armor 9, got %d", q.Get().AC)
+		}
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 spawned entity with armor, got %d", n)
+	}
+}
+
+// Test that Despawn removes the entity at the sync point and ignores
+// entities that are already dead.
+func TestCommandsDespawn(t *testing.T) {
+	app := newTestApp()
+	w := app.World()
+	e := ecs.NewMap[health](w).NewEntity(&health{HP: 1})
+
+	cmd := app.Commands()
+	cmd.Despawn(e)
+	if !w.Alive(e) {
+		t.Fatal("expected Despawn to be deferred until the sync point")
+	}
+	cmd.Despawn(e)
+	flush(t, app)
+	if w.Alive(e) {
+		t.Fatal("expected the entity to be despawned")
+	}
+
+	// Commands on a dead entity are ignored.
+	cmd.Add(e, &armor{AC: 1})
+	cmd.Remove(e, bevi.C[health]())
+	flush(t, app)
+}
+
+// Test that InsertResource adds a resource and replaces an existing one.
+func TestCommandsInsertResource(t *testing.T) {
+	app := newTestApp()
+	res := bevi.NewResource[difficulty](app.World())
+	cmd := app.Commands()
+
+	cmd.InsertResource(&difficulty{Level: 1})
+	if res.Has() {
+		t.Fatal("expected InsertResource to be deferred until the sync point")
+	}
+	flush(t, app)
+	if !res.Has() || res.Get().Level != 1 {
+		t.Fatal("expected difficulty 1 after the sync point")
+	}
+
+	cmd.InsertResource(difficulty{Level: 3})
+	flush(t, app)
+	if got := res.Get().Level; got != 3 {
+		t.Fatalf("expected InsertResource to replace the resource, got level %d", got)
+	}
+}
@@ -13,12 +13,34 @@ type Diagnostics interface {
 	EventEmit(name string, count int)
 }
 
+// EventMissDiagnostics is implemented by Diagnostics that also want to know
+// about events evicted from the retained window before a reader consumed
+// them (see EventBus.SetRetention). It is detected with a type assertion.
+type EventMissDiagnostics interface {
+	EventMissed(name string, count int)
+}
+
+// EventOverflowDiagnostics is implemented by Diagnostics that also want to
+// know about event capacity and subscription overflows. It is detected with
+// a type assertion.
+type EventOverflowDiagnostics interface {
+	// EventDropped reports events discarded by a capacity limit or a full
+	// subscription (see SetEventCapacity and Subscribe).
+	EventDropped(name string, count int)
+	// EventBlocked reports writers that waited for room under an
+	// OverflowBlock capacity limit.
+	EventBlocked(name string, count int)
+}
+
 // NopDiagnostics is a no-op diagnostics implementation.
 type NopDiagnostics struct{}
 
 func (NopDiagnostics) SystemStart(string, Stage)                     {}
 func (NopDiagnostics) SystemEnd(string, Stage, error, time.Duration) {}
 func (NopDiagnostics) EventEmit(string, int)                         {}
+func (NopDiagnostics) EventMissed(string, int)                       {}
+func (NopDiagnostics) EventDropped(string, int)                      {}
+func (NopDiagnostics) EventBlocked(string, int)                      {}
 
 // LogDiagnostics logs diagnostics to a logger interface.
 type LogDiagnostics struct {
@@ -46,6 +68,18 @@ func (d *LogDiagnostics) EventEmit(name string, count int) {
 	d.log.Printf("Event %s emitted: %d", name, count)
 }
 
+func (d *LogDiagnostics) EventMissed(name string, count int) {
+	d.log.Printf("Event %s missed by a lagging reader: %d", name, count)
+}
+
+func (d *LogDiagnostics) EventDropped(name string, count int) {
+	d.log.Printf("Event %s dropped on overflow: %d", name, count)
+}
+
+func (d *LogDiagnostics) EventBlocked(name string, count int) {
+	d.log.Printf("Event %s writers blocked on capacity: %d", name, count)
+}
+
 // internalDiagnostics adapts bevi.Diagnostics to scheduler.Diagnostics
 type internalDiagnostics struct {
 	d Diagnostics
@@ -68,3 +102,21 @@ func (da *internalDiagnostics) EventEmit(name string, count int) {
 		da.d.EventEmit(name, count)
 	}
 }
+
+func (da *internalDiagnostics) EventMissed(name string, count int) {
+	if md, ok := da.d.(EventMissDiagnostics); ok {
+		md.EventMissed(name, count)
+	}
+}
+
+func (da *internalDiagnostics) EventDropped(name string, count int) {
+	if od, ok := da.d.(EventOverflowDiagnostics); ok {
+		od.EventDropped(name, count)
+	}
+}
+
+func (da *internalDiagnostics) EventBlocked(name string, count int) {
+	if od, ok := da.d.(EventOverflowDiagnostics); ok {
+		od.EventBlocked(name, count)
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by bevi gen; DO NOT EDIT.
-// Generated at 2025-11-23T07:07:32-06:00
+// Generated at 2026-10-18T12:05:20Z
 
 package dragonfly
 
@@ -15,6 +15,7 @@ func Systems(app *bevi.App) {
 	_ew_3 := bevi.WriterFor[PlayerJoin](app.Events())
 	_er_4 := bevi.ReaderFor[playerRemove](app.Events())
 	_ew_5 := bevi.WriterFor[PlayerQuit](app.Events())
+	_er_6 := bevi.ReaderFor[playerRemove](app.Events())
 
 	// System: emitPlayerJoin (from plugin.go)
 	{
@@ -47,7 +48,7 @@ func Systems(app *bevi.App) {
 		bevi.AccessResRead[Server](&acc)
 		meta := bevi.SystemMeta{Access: acc, Set: "dragonfly", Before: nil, After: nil}
 		app.AddSystem(bevi.PostUpdate, "handlePlayerRemoval", meta, func(ctx context.Context, w *bevi.World) {
-			handlePlayerRemoval(w, _res_1, _er_4)
+			handlePlayerRemoval(w, _res_1, _er_6)
 		})
 	}
 
@@ -23,6 +23,18 @@ type Param struct {
 	Type string
 }
 
+// Shared reports whether the event carries pointers, slices or maps that
+// readers may write through. The handler then waits for every reader before
+// returning, rather than returning early on cancellation.
+func (e EventDesc) Shared() bool {
+	for _, p := range e.Params {
+		if strings.HasPrefix(p.Type, "*") || strings.HasPrefix(p.Type, "[]") || strings.HasPrefix(p.Type, "map[") {
+			return true
+		}
+	}
+	return false
+}
+
 // Domains
 var playerEvents = []EventDesc{
 	{Name: "Move", Cancellable: true, Context: "ctx *player.Context", Params: []Param{{"newPos", "mgl64.Vec3"}, {"newRot", "cube.Rotation"}}},
@@ -302,7 +314,7 @@ func (h *playerHandler) Handle{{.Name}}({{.Context}}{{range .Params}}, {{.Name}}
     {{- range .Params}}
         {{.Name | toExported}}: {{.Name}},
     {{- end}}
-    }).Wait(h.ctx) {
+    }).{{if .Shared}}Wait{{else}}WaitCancelled{{end}}(h.ctx) {
         ctx.Cancel()
     }
     {{- else}}
@@ -391,7 +403,7 @@ func (h *worldHandler) Handle{{.Name}}({{.Context}}{{range .Params}}, {{.Name}}
 	{{- range .Params}}
 		{{.Name | toExported}}: {{.Name}},
 	{{- end}}
-	}).Wait(h.ctx) {
+	}).{{if .Shared}}Wait{{else}}WaitCancelled{{end}}(h.ctx) {
 		ctx.Cancel()
 	}
 	{{- else}}
@@ -1,5 +1,5 @@
 // Code generated by bevi gen; DO NOT EDIT.
-// Generated at 2025-11-23T07:07:32-06:00
+// Generated at 2026-10-18T13:47:04Z
 
 package main
 
@@ -15,8 +15,10 @@ func Systems(app *bevi.App) {
 	_er_1 := bevi.ReaderFor[dragonfly.PlayerJoin](app.Events())
 	_flt_2 := bevi.NewFilter1[dragonfly.Player](app)
 	_er_3 := bevi.ReaderFor[dragonfly.PlayerQuit](app.Events())
-	_er_4 := bevi.ReaderFor[dragonfly.PlayerChat](app.Events())
-	_flt_5 := bevi.NewFilter1[dragonfly.Player](app)
+	_flt_4 := bevi.NewFilter1[dragonfly.Player](app)
+	_er_5 := bevi.ReaderFor[dragonfly.PlayerChat](app.Events())
+	_flt_6 := bevi.NewFilter1[dragonfly.Player](app)
+	_flt_7 := bevi.NewFilter1[dragonfly.Player](app)
 
 	// System: DenyBlockBreak (from main.go)
 	{
@@ -46,7 +48,7 @@ func Systems(app *bevi.App) {
 		bevi.AccessRead[dragonfly.Player](&acc)
 		meta := bevi.SystemMeta{Access: acc, Set: "", Before: nil, After: nil}
 		app.AddSystem(bevi.Update, "FarewellOnQuit", meta, func(ctx context.Context, w *bevi.World) {
-			FarewellOnQuit(w, _er_3, _flt_2)
+			FarewellOnQuit(w, _er_3, _flt_4)
 		})
 	}
 
@@ -57,17 +59,18 @@ func Systems(app *bevi.App) {
 		bevi.AccessRead[dragonfly.Player](&acc)
 		meta := bevi.SystemMeta{Access: acc, Set: "", Before: nil, After: nil}
 		app.AddSystem(bevi.Update, "ChatFilterAndCount", meta, func(ctx context.Context, w *bevi.World) {
-			ChatFilterAndCount(w, _er_4, _flt_2)
+			ChatFilterAndCount(w, _er_5, _flt_6)
 		})
 	}
 
 	// System: BroadcastPlayerCount (from main.go)
 	{
 		acc := bevi.NewAccess()
+		bevi.AccessWith[dragonfly.Player](&acc)
 		bevi.AccessWrite[dragonfly.Player](&acc)
 		meta := bevi.SystemMeta{Access: acc, Set: "", Before: nil, After: nil, Every: 10000000000 * time.Nanosecond}
 		app.AddSystem(bevi.Update, "BroadcastPlayerCount", meta, func(ctx context.Context, w *bevi.World) {
-			_q0 := _flt_5.Query()
+			_q0 := _flt_7.Query()
 			BroadcastPlayerCount(&_q0)
 			_q0.Close()
 		})
@@ -130,7 +130,7 @@ func (h *playerHandler) HandleMove(ctx *player.Context, newPos mgl64.Vec3, newRo
 		Player: dp,
 		NewPos: newPos,
 		NewRot: newRot,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -153,7 +153,7 @@ func (h *playerHandler) HandleTeleport(ctx *player.Context, pos mgl64.Vec3) {
 	if h.teleport.EmitResult(PlayerTeleport{
 		Player: dp,
 		Pos:    pos,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -178,7 +178,7 @@ func (h *playerHandler) HandleToggleSprint(ctx *player.Context, after bool) {
 	if h.toggleSprint.EmitResult(PlayerToggleSprint{
 		Player: dp,
 		After:  after,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -191,7 +191,7 @@ func (h *playerHandler) HandleToggleSneak(ctx *player.Context, after bool) {
 	if h.toggleSneak.EmitResult(PlayerToggleSneak{
 		Player: dp,
 		After:  after,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -307,7 +307,7 @@ func (h *playerHandler) HandleFireExtinguish(ctx *player.Context, pos cube.Pos)
 	if h.fireExtinguish.EmitResult(PlayerFireExtinguish{
 		Player: dp,
 		Pos:    pos,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -320,7 +320,7 @@ func (h *playerHandler) HandleStartBreak(ctx *player.Context, pos cube.Pos) {
 	if h.startBreak.EmitResult(PlayerStartBreak{
 		Player: dp,
 		Pos:    pos,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -349,7 +349,7 @@ func (h *playerHandler) HandleBlockPlace(ctx *player.Context, pos cube.Pos, bloc
 		Player: dp,
 		Pos:    pos,
 		Block:  block,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -363,7 +363,7 @@ func (h *playerHandler) HandleBlockPick(ctx *player.Context, pos cube.Pos, block
 		Player: dp,
 		Pos:    pos,
 		Block:  block,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -375,7 +375,7 @@ func (h *playerHandler) HandleItemUse(ctx *player.Context) {
 	}
 	if h.itemUse.EmitResult(PlayerItemUse{
 		Player: dp,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -390,7 +390,7 @@ func (h *playerHandler) HandleItemUseOnBlock(ctx *player.Context, pos cube.Pos,
 		Pos:      pos,
 		Face:     face,
 		ClickPos: clickPos,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -403,7 +403,7 @@ func (h *playerHandler) HandleItemUseOnEntity(ctx *player.Context, target world.
 	if h.itemUseOnEntity.EmitResult(PlayerItemUseOnEntity{
 		Player: dp,
 		Target: target,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -417,7 +417,7 @@ func (h *playerHandler) HandleItemRelease(ctx *player.Context, item item.Stack,
 		Player: dp,
 		Item:   item,
 		Dur:    dur,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -430,7 +430,7 @@ func (h *playerHandler) HandleItemConsume(ctx *player.Context, item item.Stack)
 	if h.itemConsume.EmitResult(PlayerItemConsume{
 		Player: dp,
 		Item:   item,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -471,7 +471,7 @@ func (h *playerHandler) HandlePunchAir(ctx *player.Context) {
 	}
 	if h.punchAir.EmitResult(PlayerPunchAir{
 		Player: dp,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -487,7 +487,7 @@ func (h *playerHandler) HandleSignEdit(ctx *player.Context, pos cube.Pos, frontS
 		FrontSide: frontSide,
 		OldText:   oldText,
 		NewText:   newText,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -516,7 +516,7 @@ func (h *playerHandler) HandleItemDamage(ctx *player.Context, item item.Stack, d
 		Player: dp,
 		Item:   item,
 		Damage: damage,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -543,7 +543,7 @@ func (h *playerHandler) HandleHeldSlotChange(ctx *player.Context, from int, to i
 		Player: dp,
 		From:   from,
 		To:     to,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
@@ -556,7 +556,7 @@ func (h *playerHandler) HandleItemDrop(ctx *player.Context, item item.Stack) {
 	if h.itemDrop.EmitResult(PlayerItemDrop{
 		Player: dp,
 		Item:   item,
-	}).Wait(h.ctx) {
+	}).WaitCancelled(h.ctx) {
 		ctx.Cancel()
 	}
 }
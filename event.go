@@ -2,6 +2,11 @@ package bevi
 
 import (
 	"context"
+	"iter"
+	"net"
+	"os"
+	"reflect"
+	"sync"
 
 	"github.com/oriumgames/bevi/internal/event"
 )
@@ -18,6 +23,95 @@ type EventReader[T any] = event.Reader[T]
 // EventResult is the public alias for the internal events.EventResult[T].
 type EventResult[T any] = event.EventResult[T]
 
+// EventCompletion is implemented by every EventResult[T], allowing results of
+// different event types to be awaited together.
+type EventCompletion = event.Completion
+
+// RequestWriter emits request events of type T that readers answer with R.
+type RequestWriter[T, R any] = event.RequestWriter[T, R]
+
+// RequestReader consumes request events of type T and replies with R.
+type RequestReader[T, R any] = event.RequestReader[T, R]
+
+// Request is the handle of an emitted request; Await returns the merged reply.
+type Request[T, R any] = event.Request[T, R]
+
+// Merge selects how the replies to a request are combined.
+type Merge[R any] = event.Merge[R]
+
+// EventPriority orders the readers of an event type; see AccessEventPriority.
+type EventPriority = event.Priority
+
+// Reader priorities, from first to last. PriorityNormal is the default.
+const (
+	PriorityLowest  = event.Lowest
+	PriorityLow     = event.Low
+	PriorityNormal  = event.Normal
+	PriorityHigh    = event.High
+	PriorityHighest = event.Highest
+	PriorityMonitor = event.Monitor
+)
+
+// ReaderOptions configures readers created with ReaderWith.
+type ReaderOptions = event.ReaderOptions
+
+// SubscribeOptions configures subscriptions created with Subscribe or
+// SubscribeSeq.
+type SubscribeOptions = event.SubscribeOptions
+
+// Overflow selects what a subscription does when its buffer is full.
+type Overflow = event.Overflow
+
+// Overflow policies for subscriptions and event capacities. OverflowBlock is
+// the default; OverflowCoalesce only applies to capacities.
+const (
+	OverflowBlock      = event.Block
+	OverflowDropOldest = event.DropOldest
+	OverflowDropNewest = event.DropNewest
+	OverflowCoalesce   = event.Coalesce
+)
+
+// ScheduledEvent is the handle of an event emitted with EventWriter.EmitAfter
+// or EventWriter.EmitAtFrame; Cancel stops a pending delivery.
+type ScheduledEvent = event.Scheduled
+
+// EventCapacity bounds the events of one type pending for the next frame.
+type EventCapacity[T any] = event.Capacity[T]
+
+// EventStats describes one event type on a bus; see EventBus.Stats.
+type EventStats = event.Stats
+
+// EventJournal records the events published on a bus; see App.RecordEvents.
+type EventJournal = event.Journal
+
+// EventReplay injects the events of a journal into a bus; see
+// App.ReplayEvents.
+type EventReplay = event.Replay
+
+// JournalCodec encodes event journals. JournalGob and JournalJSON are
+// built in; any stream codec with Encode/Decode methods can be plugged in.
+type JournalCodec = event.Codec
+
+// Built-in journal codecs. JournalGob is the default.
+var (
+	JournalGob  = event.Gob
+	JournalJSON = event.JSON
+)
+
+// EventLink forwards selected event types between buses in different
+// processes; see DialEvents, AcceptEvents and LoopbackEvents.
+type EventLink = event.Link
+
+// ForwardOptions configures how ForwardEvents forwards an event type.
+type ForwardOptions = event.SendOptions
+
+// EventStream reads several event types in emission order; see
+// EventStreamFor.
+type EventStream = event.Stream
+
+// EventSource selects an event type of an EventStream; see StreamOf.
+type EventSource = event.Source
+
 // NewEventBus constructs a new event bus.
 func NewEventBus() *EventBus {
 	return event.NewBus()
@@ -28,22 +122,222 @@ func WriterFor[T any](bus *EventBus) EventWriter[T] {
 	return event.WriterFor[T](bus)
 }
 
-// ReaderFor returns a typed EventReader bound to the given bus.
+// ReaderFor returns a new typed EventReader bound to the given bus. Each
+// reader has its own cursor and sees every event exactly once. For an
+// interface type T the reader fans in every event type implementing T.
 func ReaderFor[T any](bus *EventBus) EventReader[T] {
 	return event.ReaderFor[T](bus)
 }
 
+// HasReaders reports whether events of type T have a reader on the bus, either
+// created with ReaderFor or declared through a system's AccessEventRead.
+// Bridges can use it to skip building and emitting events nobody consumes.
+func HasReaders[T any](bus *EventBus) bool {
+	return event.HasReaders[T](bus)
+}
+
+// ReaderWith returns a new typed EventReader with the given options, e.g. to
+// skip events cancelled by lower-priority readers.
+func ReaderWith[T any](bus *EventBus, opts ReaderOptions) EventReader[T] {
+	return event.ReaderWith[T](bus, opts)
+}
+
+// Subscribe returns a channel receiving every event of type T published on
+// the bus, for code outside the schedule such as HTTP handlers or metrics
+// exporters. Events are delivered at Advance into a bounded buffer handled by
+// opts.Overflow, and the channel is closed once ctx is cancelled.
+func Subscribe[T any](ctx context.Context, bus *EventBus, opts SubscribeOptions) <-chan T {
+	return event.Subscribe[T](ctx, bus, opts)
+}
+
+// SubscribeSeq is like Subscribe but returns an iterator; breaking out of the
+// range loop ends the subscription.
+func SubscribeSeq[T any](ctx context.Context, bus *EventBus, opts SubscribeOptions) iter.Seq[T] {
+	return event.SubscribeSeq[T](ctx, bus, opts)
+}
+
+// SetEventRetention sets how many frames events of type T stay readable,
+// overriding EventBus.SetRetention. Readers that run less often than that
+// (e.g. with Every) miss events and report them via EventMissDiagnostics.
+func SetEventRetention[T any](bus *EventBus, frames int) {
+	event.SetRetentionFor[T](bus, frames)
+}
+
+// SetEventCapacity limits how many events of type T may be pending for the
+// next Advance, protecting memory against floods (e.g. movement packets from
+// many connections) and stalled frames. Writes beyond c.Max are handled by
+// c.Overflow and reported via EventOverflowDiagnostics. Don't
+// use OverflowBlock without a Timeout for events emitted by systems.
+func SetEventCapacity[T any](bus *EventBus, c EventCapacity[T]) {
+	event.SetCapacity(bus, c)
+}
+
+// SetEventMerge sets how EventWriter.EmitKeyed merges an event of type T into
+// the pending event with the same key, e.g. to union dirty chunk ranges. By
+// default the newer event replaces the pending one.
+func SetEventMerge[T any](bus *EventBus, merge func(prev, next T) T) {
+	event.SetKeyedMerge(bus, merge)
+}
+
+// SequenceEvents stamps the events of type T with a sequence number shared
+// by every sequenced type on the bus, exposed by EventReader.Seq, so events
+// of different types can be ordered by emission. Sequence types before they
+// are emitted; EventStreamFor sequences its types itself.
+func SequenceEvents[T any](bus *EventBus) {
+	event.Sequence[T](bus)
+}
+
+// StreamOf selects the events of type T for an EventStream.
+func StreamOf[T any]() EventSource {
+	return event.Of[T]()
+}
+
+// EventStreamFor returns a reader of several event types that yields their
+// events in emission order, e.g. for audit logs or state reconstruction.
+func EventStreamFor(bus *EventBus, sources ...EventSource) *EventStream {
+	return event.StreamFor(bus, sources...)
+}
+
+// EventStreamWith is like EventStreamFor but applies the given options to
+// every type.
+func EventStreamWith(bus *EventBus, opts ReaderOptions, sources ...EventSource) *EventStream {
+	return event.StreamWith(bus, opts, sources...)
+}
+
+// RegisterEventType makes events of type T known to the bus before any
+// writer or reader for it exists, so App.ReplayEvents can inject them.
+func RegisterEventType[T any](bus *EventBus) {
+	event.RegisterType[T](bus)
+}
+
+// RecordEvents records every event published by the App, frame by frame, to
+// the journal file at path, encoded with codec (JournalGob if nil). Close the
+// returned journal to stop recording and close the file. Request events are
+// not recorded.
+func (a *App) RecordEvents(path string, codec JournalCodec) (*EventJournal, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return a.events.Record(f, codec), nil
+}
+
+// ReplayEvents injects the events recorded by RecordEvents at path into the
+// App, frame by frame, starting with the current frame. Call it on a fresh
+// App before Run: while the journal lasts, live writes of recorded types are
+// discarded, so with a deterministic schedule (SetExecMode(Sequential) and a
+// FrameClock) the App reproduces the recorded run. Every recorded type must
+// be known to the bus through a system's writer or reader, or
+// RegisterEventType. The file is closed when the replay ends.
+func (a *App) ReplayEvents(path string, codec JournalCodec) (*EventReplay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := a.events.Replay(f, codec)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// DialEvents connects bus to a peer listening on a "tcp" or "unix" address.
+// Links use the journal codecs (JournalGob if nil); both ends must agree.
+func DialEvents(ctx context.Context, network, addr string, bus *EventBus, codec JournalCodec) (*EventLink, error) {
+	return event.Dial(ctx, network, addr, bus, codec)
+}
+
+// AcceptEvents waits for the next peer on ln and links it to bus.
+func AcceptEvents(ln net.Listener, bus *EventBus, codec JournalCodec) (*EventLink, error) {
+	return event.Accept(ln, bus, codec)
+}
+
+// LoopbackEvents links two buses in memory, for tests.
+func LoopbackEvents(a, b *EventBus, codec JournalCodec) (*EventLink, *EventLink) {
+	return event.Loopback(a, b, codec)
+}
+
+// ForwardEvents forwards the events of type T published on the link's bus
+// to the peer, which must accept them with ReceiveEvents. With
+// opts.Results, EventResults wait for the peer's readers and report their
+// cancellations.
+func ForwardEvents[T any](link *EventLink, opts ForwardOptions) {
+	event.Send[T](link, opts)
+}
+
+// ReceiveEvents accepts events of type T from the peer into the write buffer
+// of the link's bus, so they are published by its next frame. Call it before
+// link.Start.
+func ReceiveEvents[T any](link *EventLink) {
+	event.Receive[T](link)
+}
+
+// RequestWriterFor returns a typed RequestWriter bound to the given bus.
+func RequestWriterFor[T, R any](bus *EventBus) RequestWriter[T, R] {
+	return event.RequestWriterFor[T, R](bus)
+}
+
+// RequestReaderFor returns a new typed RequestReader bound to the given bus.
+// Like EventReader, each request reader has its own cursor.
+func RequestReaderFor[T, R any](bus *EventBus) RequestReader[T, R] {
+	return event.RequestReaderFor[T, R](bus)
+}
+
+// RequestReaderWith is like RequestReaderFor but applies the given options.
+func RequestReaderWith[T, R any](bus *EventBus, opts ReaderOptions) RequestReader[T, R] {
+	return event.RequestReaderWith[T, R](bus, opts)
+}
+
+// SetRequestMerge sets the merge policy for requests T -> R on the bus. The
+// default is MergeFirst.
+func SetRequestMerge[T, R any](bus *EventBus, m Merge[R]) {
+	event.SetRequestMerge[T, R](bus, m)
+}
+
+// MergeFirst keeps the first reply; Await returns as soon as it arrives.
+func MergeFirst[R any]() Merge[R] {
+	return event.First[R]()
+}
+
+// MergeLast keeps the last reply once the request has completed.
+func MergeLast[R any]() Merge[R] {
+	return event.Last[R]()
+}
+
+// MergeReduce folds all replies into init with fn once the request has completed.
+func MergeReduce[R any](init R, fn func(acc, next R) R) Merge[R] {
+	return event.Reduce(init, fn)
+}
+
+// WaitAll blocks until every result has completed or ctx is done. It reports
+// whether any of them was cancelled, and ctx.Err() if ctx ended first.
+func WaitAll(ctx context.Context, results ...EventCompletion) (bool, error) {
+	return event.WaitAll(ctx, results...)
+}
+
+// WaitAny blocks until one of the results has completed or ctx is done. It
+// returns the index of the first completed result, or -1 and ctx.Err().
+func WaitAny(ctx context.Context, results ...EventCompletion) (int, error) {
+	return event.WaitAny(ctx, results...)
+}
+
+// ctxBus is the value attached by WithEventBus: the bus and the readers
+// ReaderFromContext registered for the context, one per event type.
+type ctxBus struct {
+	bus     *EventBus
+	readers sync.Map // reflect.Type -> *EventReader[T]
+}
+
 // WithEventBus attaches the EventBus to the provided context.
 func WithEventBus(parent context.Context, bus *EventBus) context.Context {
-	return context.WithValue(parent, eventBusCtxKey{}, bus)
+	return context.WithValue(parent, eventBusCtxKey{}, &ctxBus{bus: bus})
 }
 
 // EventBusFrom extracts the EventBus from the context if present, or nil.
 func EventBusFrom(ctx context.Context) *EventBus {
-	if v := ctx.Value(eventBusCtxKey{}); v != nil {
-		if b, ok := v.(*event.Bus); ok {
-			return b
-		}
+	if cb, ok := ctx.Value(eventBusCtxKey{}).(*ctxBus); ok {
+		return cb.bus
 	}
 	return nil
 }
@@ -58,14 +352,28 @@ func WriterFromContext[T any](ctx context.Context) EventWriter[T] {
 	return zero
 }
 
-// ReaderFromContext fetches a typed EventReader from context if a bus is present.
-// Returns a zero-value reader if none is found.
+// ReaderFromContext fetches a typed EventReader from context if a bus is
+// present. The reader is registered on the first call for T and reused by
+// every later call with a context derived from the same WithEventBus, so
+// they share its cursor. Closing it closes it for all of them. Returns a
+// zero-value reader if no bus is found.
 func ReaderFromContext[T any](ctx context.Context) EventReader[T] {
-	if bus := EventBusFrom(ctx); bus != nil {
-		return ReaderFor[T](bus)
+	cb, ok := ctx.Value(eventBusCtxKey{}).(*ctxBus)
+	if !ok || cb.bus == nil {
+		var zero EventReader[T]
+		return zero
 	}
-	var zero EventReader[T]
-	return zero
+	t := reflect.TypeFor[T]()
+	if v, ok := cb.readers.Load(t); ok {
+		return *v.(*EventReader[T])
+	}
+	r := ReaderFor[T](cb.bus)
+	if v, loaded := cb.readers.LoadOrStore(t, &r); loaded {
+		// Another caller registered it first.
+		r.Close()
+		return *v.(*EventReader[T])
+	}
+	return r
 }
 
 type eventBusCtxKey struct{}
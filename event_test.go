@@ -0,0 +1,55 @@
+package bevi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oriumgames/bevi"
+)
+
+// Test that ReaderFromContext registers one reader per type and context
+// instead of one per call, and that closing it releases pending results.
+func TestReaderFromContext(t *testing.T) {
+	bus := bevi.NewEventBus()
+	ctx := bevi.WithEventBus(context.Background(), bus)
+	w := bevi.WriterFor[int](bus)
+
+	r := bevi.ReaderFromContext[int](ctx)
+	for range 10 {
+		bevi.ReaderFromContext[int](ctx)
+	}
+	if st := bus.Stats(); len(st) != 1 || st[0].Readers != 1 {
+		t.Fatalf("expected 1 registered reader, got %+v", st)
+	}
+
+	// Calls share the cursor.
+	w.Emit(1)
+	bus.Advance()
+	again := bevi.ReaderFromContext[int](ctx)
+	if got := again.Drain(); len(got) != 1 {
+		t.Fatalf("expected 1 event, got %v", got)
+	}
+	if got := r.Drain(); len(got) != 0 {
+		t.Fatalf("expected the shared cursor to be consumed, got %v", got)
+	}
+
+	res := w.EmitResult(2)
+	bus.Advance()
+	r.Close()
+	if !isDone(res.Done()) {
+		t.Fatal("expected Close to release the pending result")
+	}
+
+	if got := bevi.ReaderFromContext[int](context.Background()).Drain(); got != nil {
+		t.Fatalf("expected a zero reader without a bus, got %v", got)
+	}
+}
+
+func isDone(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by bevi gen; DO NOT EDIT.
-// Generated at 2025-11-23T07:07:32-06:00
+// Generated at 2026-10-18T13:47:04Z
 
 package main
 
@@ -18,7 +18,9 @@ func Systems(app *bevi.App) {
 	_er_5 := bevi.ReaderFor[BonusEvent](app.Events())
 	_flt_6 := bevi.NewFilter1[Test](app)
 	_er_7 := bevi.ReaderFor[TickEvent](app.Events())
-	_er_8 := bevi.ReaderFor[CancelEvent](app.Events())
+	_flt_8 := bevi.NewFilter1[Test](app)
+	_er_9 := bevi.ReaderFor[CancelEvent](app.Events())
+	_flt_10 := bevi.NewFilter1[Test](app)
 
 	// System: Creation (from main.go)
 	{
@@ -45,6 +47,7 @@ func Systems(app *bevi.App) {
 		acc := bevi.NewAccess()
 		bevi.AccessEventWrite[BonusEvent](&acc)
 		bevi.AccessEventWrite[CancelEvent](&acc)
+		bevi.AccessWith[Test](&acc)
 		bevi.AccessWrite[Test](&acc)
 		meta := bevi.SystemMeta{Access: acc, Set: "", Before: nil, After: []string{"Tick"}, Every: 1000000000 * time.Nanosecond}
 		app.AddSystem(bevi.Update, "IncreaseMoney", meta, func(ctx context.Context, w *bevi.World) {
@@ -78,10 +81,11 @@ func Systems(app *bevi.App) {
 	// System: PrintMoney (from main.go)
 	{
 		acc := bevi.NewAccess()
+		bevi.AccessWith[Test](&acc)
 		bevi.AccessWrite[Test](&acc)
 		meta := bevi.SystemMeta{Access: acc, Set: "", Before: nil, After: []string{"IncreaseMoney", "BonusConsumer"}, Every: 1000000000 * time.Nanosecond}
 		app.AddSystem(bevi.Update, "PrintMoney", meta, func(ctx context.Context, w *bevi.World) {
-			_q0 := _flt_4.Query()
+			_q0 := _flt_8.Query()
 			PrintMoney(&_q0)
 			_q0.Close()
 		})
@@ -93,17 +97,18 @@ func Systems(app *bevi.App) {
 		bevi.AccessEventRead[CancelEvent](&acc)
 		meta := bevi.SystemMeta{Access: acc, Set: "", Before: nil, After: nil}
 		app.AddSystem(bevi.Update, "CancelConsumer", meta, func(ctx context.Context, w *bevi.World) {
-			CancelConsumer(_er_8)
+			CancelConsumer(_er_9)
 		})
 	}
 
 	// System: Audit (from main.go)
 	{
 		acc := bevi.NewAccess()
+		bevi.AccessWith[Test](&acc)
 		bevi.AccessWrite[Test](&acc)
 		meta := bevi.SystemMeta{Access: acc, Set: "", Before: nil, After: []string{"PrintMoney"}, Every: 1500000000 * time.Nanosecond}
 		app.AddSystem(bevi.Update, "Audit", meta, func(ctx context.Context, w *bevi.World) {
-			_q0 := _flt_4.Query()
+			_q0 := _flt_10.Query()
 			Audit(&_q0)
 			_q0.Close()
 		})
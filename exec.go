@@ -0,0 +1,96 @@
+package bevi
+
+import (
+	"sync"
+	"time"
+)
+
+// ExecMode selects how the App executes the systems of a stage.
+type ExecMode int
+
+const (
+	// Parallel runs conflict-free batches of systems concurrently on a
+	// worker pool sized to GOMAXPROCS. This is the default.
+	Parallel ExecMode = iota
+	// Sequential runs every system of a stage one after another in
+	// deterministic topological order on the App's goroutine. Commands are
+	// applied right after each system that records them.
+	Sequential
+)
+
+// String returns the string representation of an execution mode.
+func (m ExecMode) String() string {
+	switch m {
+	case Parallel:
+		return "Parallel"
+	case Sequential:
+		return "Sequential"
+	default:
+		return "Unknown"
+	}
+}
+
+// RunMode selects when App.Run starts a frame.
+type RunMode int
+
+const (
+	// Continuous runs frames back to back. This is the default.
+	Continuous RunMode = iota
+	// Reactive parks the App between frames until there is something to do:
+	// an event emitted from outside the schedule, the next Every deadline of
+	// a system, a due EmitAfter event, or App.Wake. Frames only run when
+	// needed, so an idle App uses next to no CPU.
+	Reactive
+)
+
+// String returns the string representation of a run mode.
+func (m RunMode) String() string {
+	switch m {
+	case Continuous:
+		return "Continuous"
+	case Reactive:
+		return "Reactive"
+	default:
+		return "Unknown"
+	}
+}
+
+// Clock supplies the time used for Every gating and diagnostics durations.
+type Clock interface {
+	Now() time.Time
+}
+
+// frameAdvancer is implemented by clocks that move once per frame.
+type frameAdvancer interface {
+	Advance()
+}
+
+// FrameClock is a deterministic Clock that only moves when the App finishes
+// a frame: after n frames Now returns start + n*step. Combined with the
+// Sequential mode it makes Every gating reproducible across runs.
+type FrameClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+// NewFrameClock creates a FrameClock starting at start and advancing by step
+// per frame.
+func NewFrameClock(start time.Time, step time.Duration) *FrameClock {
+	return &FrameClock{now: start, step: step}
+}
+
+// Now returns the current frame time.
+func (c *FrameClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by one step. The App calls it at the end of
+// every frame.
+func (c *FrameClock) Advance() {
+	c.mu.Lock()
+	c.now = c.now.Add(c.step)
+	c.mu.Unlock()
+}
@@ -2,7 +2,10 @@ package event
 
 import (
 	"reflect"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Diagnostics is the interface for event system diagnostics.
@@ -10,15 +13,115 @@ type Diagnostics interface {
 	EventEmit(name string, count int)
 }
 
+// MissDiagnostics is implemented by Diagnostics that want to know about
+// events evicted from the retained window before a reader consumed them.
+type MissDiagnostics interface {
+	EventMissed(name string, count int)
+}
+
+// OverflowDiagnostics is implemented by Diagnostics that want to know about
+// capacity and subscription overflows.
+type OverflowDiagnostics interface {
+	// EventDropped reports events discarded by a capacity or subscription
+	// overflow policy.
+	EventDropped(name string, count int)
+	// EventBlocked reports writers that had to wait for room under the Block
+	// capacity policy.
+	EventBlocked(name string, count int)
+}
+
+// DefaultRetention is the number of frames an event stays readable after the
+// Advance that published it.
+const DefaultRetention = 1
+
 // Bus is a high-performance, per-type event system with frame-based delivery.
 type Bus struct {
-	stores sync.Map // key: reflect.Type, value: *store[T]
-	diag   Diagnostics
+	stores    sync.Map // key: reflect.Type, value: *store[T]
+	merges    sync.Map // key: request reflect.Type, value: Merge[R]
+	declared  sync.Map // key: reflect.Type, value: *atomic.Int32
+	diag      Diagnostics
+	mu        sync.Mutex
+	retention int
+	timers    timers
+	gen       atomic.Uint64  // incremented whenever a store is created
+	ifaces    []reflect.Type // interface types declared via DeclareReader, guarded by mu
+	journal   atomic.Pointer[Journal]
+	replay    atomic.Pointer[Replay]
+	replaying atomic.Bool // live writes of journaled types are discarded
+	wakeup    wakeup
+	published atomic.Bool   // the latest Advance published events
+	seq       atomic.Uint64 // global sequence shared by sequenced types
 }
 
 // NewBus constructs a Bus.
 func NewBus() *Bus {
-	return &Bus{}
+	b := &Bus{retention: DefaultRetention}
+	b.wakeup.ch = make(chan struct{}, 1)
+	return b
+}
+
+// SetRetention sets how many frames events stay readable for every event type
+// without an explicit per-type retention, including types created later.
+// Readers that fall further behind miss events and report them via
+// MissDiagnostics.EventMissed. Values below 1 are treated as 1.
+func (b *Bus) SetRetention(frames int) {
+	b.mu.Lock()
+	b.retention = max(frames, 1)
+	b.mu.Unlock()
+	b.stores.Range(func(_, v any) bool {
+		if r, ok := v.(retainer); ok && !r.customRetention() {
+			r.setRetention(frames)
+		}
+		return true
+	})
+}
+
+// SetRetentionFor sets how many frames events of type T stay readable,
+// overriding the bus-wide retention.
+func SetRetentionFor[T any](b *Bus, frames int) {
+	st := ensureStore[T](b)
+	st.setRetention(frames)
+	st.mu.Lock()
+	st.custom = true
+	st.mu.Unlock()
+}
+
+// Capacity bounds the number of events of one type pending for the next
+// Advance.
+type Capacity[T any] struct {
+	// Max is the maximum number of pending events; 0 means unbounded.
+	Max int
+	// Overflow is the policy applied to writes beyond Max. The zero value is
+	// Block.
+	Overflow Overflow
+	// Timeout limits how long a writer waits under Block before its event is
+	// dropped; 0 waits until the next Advance.
+	Timeout time.Duration
+	// Coalesce merges the new event into the newest pending one under
+	// Coalesce. If nil, the new event replaces it.
+	Coalesce func(prev, next T) T
+}
+
+// SetCapacity bounds the pending events of type T. Dropped and blocked writes
+// are reported via OverflowDiagnostics.
+// Under Block, a writer waits for Advance, so systems emitting T must not rely
+// on it without a Timeout: Advance only runs after they return.
+func SetCapacity[T any](b *Bus, c Capacity[T]) {
+	st := ensureStore[T](b)
+	st.mu.Lock()
+	st.capacity = c
+	st.limited.Store(c.Max > 0)
+	st.mu.Unlock()
+}
+
+// SetKeyedMerge sets how EmitKeyed merges an event of type T into the pending
+// event with the same key. Without one, the newer event replaces the pending
+// one.
+func SetKeyedMerge[T any](b *Bus, merge func(prev, next T) T) {
+	st := ensureStore[T](b)
+	st.mu.Lock()
+	st.keyedMerge = merge
+	st.mu.Unlock()
 }
 
 // SetDiagnostics sets the diagnostics implementation.
@@ -32,30 +135,149 @@ func (b *Bus) SetDiagnostics(d Diagnostics) {
 	})
 }
 
-// Advance flips write->read buffers for all event types.
+// Advance flips write->read buffers for all event types, then starts the next
+// frame by releasing the events scheduled with EmitAfter and EmitAtFrame that
+// are due into the new write buffers. The published events are written to the
+// journal set with Record, and a Replay injects the events of the new frame.
 func (b *Bus) Advance() {
+	j := b.journal.Load()
+	frame := b.Frame()
+	published := false
+	b.stores.Range(func(_, v any) bool {
+		if adv, ok := v.(advancer); ok && adv.advance(frame, j) {
+			published = true
+		}
+		return true
+	})
+	b.published.Store(published)
+	if j != nil {
+		j.flush(frame)
+	}
+	b.timers.advance()
+	if p := b.replay.Load(); p != nil {
+		p.inject(b.Frame())
+	}
+}
+
+// Frame returns the number of Advance calls so far, i.e. the current frame as
+// used by EmitAtFrame.
+func (b *Bus) Frame() uint64 {
+	b.timers.mu.Lock()
+	defer b.timers.mu.Unlock()
+	return b.timers.frame
+}
+
+// SetClock sets the time source EmitAfter measures delays against. A nil
+// function, the default, uses time.Now.
+func (b *Bus) SetClock(now func() time.Time) {
+	b.timers.mu.Lock()
+	b.timers.now = now
+	b.timers.mu.Unlock()
+}
+
+// CompleteNoReader completes the published events of every type that has no
+// registered reader, e.g. types only consumed through declared access
+// metadata without a ReaderFor cursor. The App calls it before Advance.
+func (b *Bus) CompleteNoReader() {
 	b.stores.Range(func(_, v any) bool {
-		if adv, ok := v.(advancer); ok {
-			adv.advance()
+		if c, ok := v.(completer); ok {
+			c.completeNoReader()
+		}
+		return true
+	})
+}
+
+// DeclareReader records that a system reads events stored under t, so events
+// of that type are not completed at emit time even before the system has
+// created its reader. App.AddSystem declares every AccessMeta.EventReads type.
+// Declaring an interface type declares every event type implementing it,
+// including types stored later.
+func (b *Bus) DeclareReader(t reflect.Type) {
+	t = baseType(t)
+	if t.Kind() != reflect.Interface {
+		b.declaredFor(t).Add(1)
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.declaredFor(t).Add(1)
+	b.ifaces = append(b.ifaces, t)
+	b.stores.Range(func(k, v any) bool {
+		if fn, ok := v.(fanner); ok && fn.elemType() != t && fn.elemType().Implements(t) {
+			b.declaredFor(k.(reflect.Type)).Add(1)
 		}
 		return true
 	})
 }
 
+// HasReaders reports whether events of type T can be read, i.e. a reader was
+// created with ReaderFor or declared via DeclareReader. Writers can use it to
+// skip building events nobody consumes.
+func HasReaders[T any](b *Bus) bool {
+	return ensureStore[T](b).hasReaders()
+}
+
+func (b *Bus) declaredFor(t reflect.Type) *atomic.Int32 {
+	if v, ok := b.declared.Load(t); ok {
+		return v.(*atomic.Int32)
+	}
+	v, _ := b.declared.LoadOrStore(t, new(atomic.Int32))
+	return v.(*atomic.Int32)
+}
+
+// Types returns the event types stored on the bus, sorted by name. Request
+// types appear under their internal request type.
+func (b *Bus) Types() []reflect.Type {
+	var types []reflect.Type
+	b.stores.Range(func(_, v any) bool {
+		if fn, ok := v.(fanner); ok {
+			types = append(types, fn.elemType())
+		}
+		return true
+	})
+	slices.SortFunc(types, compareTypes)
+	return types
+}
+
 // WriterFor returns a type-safe writer bound to this bus.
 func WriterFor[T any](b *Bus) Writer[T] {
 	return Writer[T]{store: ensureStore[T](b)}
 }
 
-// ReaderFor returns a type-safe reader bound to this bus.
+// ReaderFor returns a new type-safe reader bound to this bus. Each reader
+// keeps its own cursor, starting at the newest readable frame, and sees every
+// event exactly once. Copies of a reader share its cursor. The reader stays
+// registered until Close, and events complete once every registered reader
+// has processed them.
+//
+// If T is an interface type, the reader fans in every event type on the bus
+// that implements T, including types first emitted after the reader was
+// created: it reads each type in turn, ordered by type name, and each type's
+// events in emission order. It registers as a reader of every such type.
 func ReaderFor[T any](b *Bus) Reader[T] {
-	return Reader[T]{store: ensureStore[T](b)}
+	return ReaderWith[T](b, ReaderOptions{})
+}
+
+// ReaderWith is like ReaderFor but applies the given reader options.
+func ReaderWith[T any](b *Bus, opts ReaderOptions) Reader[T] {
+	if reflect.TypeFor[T]().Kind() == reflect.Interface {
+		return Reader[T]{fan: newFanIn[T](b, opts), opts: opts}
+	}
+	st := ensureStore[T](b)
+	return Reader[T]{store: st, state: st.newReader(), opts: opts}
 }
 
 // advancer and completer are implemented by the per-type store to support
 // frame advancement and completion handling.
-type advancer interface{ advance() }
+type advancer interface {
+	advance(frame uint64, j *Journal) bool
+}
+type completer interface{ completeNoReader() }
 type diagnoser interface{ setDiagnostics(Diagnostics) }
+type retainer interface {
+	setRetention(int)
+	customRetention() bool
+}
 
 func (s *store[T]) setDiagnostics(d Diagnostics) {
 	s.diag = d
@@ -65,15 +287,35 @@ func (s *store[T]) setDiagnostics(d Diagnostics) {
 func ensureStore[T any](b *Bus) *store[T] {
 	t := baseType(reflect.TypeOf((*T)(nil)).Elem())
 
+	if v, ok := b.stores.Load(t); ok {
+		return v.(*store[T])
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	if v, ok := b.stores.Load(t); ok {
 		return v.(*store[T])
 	}
 	st := &store[T]{
-		name: t.String(),
-		diag: b.diag,
+		name:      t.String(),
+		diag:      b.diag,
+		retention: max(b.retention, 1),
+		declared:  b.declaredFor(t),
+		timers:    &b.timers,
+		shards:    newShards[T](),
+		replaying: &b.replaying,
+		wakeup:    &b.wakeup,
+		journaled: journaled(reflect.TypeFor[T]()),
+	}
+	// Interface readers declared earlier also read this type.
+	elem := reflect.TypeFor[T]()
+	for _, i := range b.ifaces {
+		if elem != i && elem.Implements(i) {
+			st.declared.Add(1)
+		}
 	}
-	actual, _ := b.stores.LoadOrStore(t, st)
-	return actual.(*store[T])
+	b.stores.Store(t, st)
+	b.gen.Add(1)
+	return st
 }
 
 func baseType(t reflect.Type) reflect.Type {
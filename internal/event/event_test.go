@@ -1,8 +1,12 @@
 package event_test
 
 import (
+	"bytes"
 	"context"
+	"net"
+	"reflect"
 	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -372,15 +376,13 @@ func TestEmitAndWaitConvenience(t *testing.T) {
 	}
 }
 
-func TestWaitTimeoutWhenNoReaders(t *testing.T) {
+func TestWaitImmediateWhenNoReaders(t *testing.T) {
 	b := event.NewBus()
 	w := event.WriterFor[int](b)
 
+	// No readers; the event completes at emit time, without an Advance.
 	res := w.EmitResult(1)
-	b.Advance()
-
-	// No readers; Wait should return false after context timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	begin := time.Now()
 	c := res.Wait(ctx)
@@ -388,9 +390,8 @@ func TestWaitTimeoutWhenNoReaders(t *testing.T) {
 	if c {
 		t.Fatalf("unexpected cancellation when no readers")
 	}
-	// It should roughly honor context timeouts (we allow a wide margin).
-	if dur < 5*time.Millisecond || dur > 500*time.Millisecond {
-		t.Fatalf("Wait duration unexpected: %v", dur)
+	if dur > 10*time.Millisecond {
+		t.Fatalf("Wait took too long with no readers: %v", dur)
 	}
 }
 
@@ -573,3 +574,1443 @@ func TestCancelFromMultipleReadersOnlySetsFlagOnce(t *testing.T) {
 		t.Fatalf("no reader cancelled, expected at least one")
 	}
 }
+
+type missCounter struct {
+	missed  atomic.Int32
+	dropped atomic.Int32
+	blocked atomic.Int32
+}
+
+func (*missCounter) EventEmit(string, int) {}
+func (m *missCounter) EventMissed(_ string, n int) {
+	m.missed.Add(int32(n))
+}
+func (m *missCounter) EventDropped(_ string, n int) {
+	m.dropped.Add(int32(n))
+}
+func (m *missCounter) EventBlocked(_ string, n int) {
+	m.blocked.Add(int32(n))
+}
+
+// emitOnly implements only the required Diagnostics methods.
+type emitOnly struct{}
+
+func (emitOnly) EventEmit(string, int) {}
+
+func TestReaderCursorRetention(t *testing.T) {
+	b := event.NewBus()
+	diag := &missCounter{}
+	b.SetDiagnostics(diag)
+	event.SetRetentionFor[int](b, 3)
+	w := event.WriterFor[int](b)
+	fast := event.ReaderFor[int](b)
+	slow := event.ReaderFor[int](b)
+
+	// The fast reader runs every frame, twice; the slow one every third frame.
+	var fastGot, slowGot []int
+	for frame := range 6 {
+		w.Emit(frame)
+		b.Advance()
+		fastGot = append(fastGot, collect(&fast)...)
+		fastGot = append(fastGot, collect(&fast)...)
+		if frame%3 == 2 {
+			slowGot = append(slowGot, collect(&slow)...)
+		}
+	}
+	want := []int{0, 1, 2, 3, 4, 5}
+	if len(fastGot) != len(want) || len(slowGot) != len(want) {
+		t.Fatalf("expected each reader to see %v once, got fast=%v slow=%v", want, fastGot, slowGot)
+	}
+	for i := range want {
+		if fastGot[i] != want[i] || slowGot[i] != want[i] {
+			t.Fatalf("event[%d]: fast=%v slow=%v, want %v", i, fastGot, slowGot, want)
+		}
+	}
+	if diag.missed.Load() != 0 {
+		t.Fatalf("unexpected missed events: %d", diag.missed.Load())
+	}
+
+	// Falling further behind than the retention window reports the overflow.
+	for i := range 5 {
+		w.Emit(100 + i)
+		b.Advance()
+	}
+	got := slow.Drain()
+	if len(got) != 3 || got[0] != 102 {
+		t.Fatalf("expected the last 3 retained events, got %v", got)
+	}
+	if diag.missed.Load() != 2 {
+		t.Fatalf("expected 2 missed events, got %d", diag.missed.Load())
+	}
+
+	// A reader created late starts at the newest frame.
+	late := event.ReaderFor[int](b)
+	if got := collect(&late); len(got) != 1 || got[0] != 104 {
+		t.Fatalf("late reader: expected [104], got %v", got)
+	}
+
+	// Diagnostics without the optional methods are fine; misses and drops are
+	// just not reported.
+	b.SetDiagnostics(emitOnly{})
+	for i := range 5 {
+		w.Emit(200 + i)
+		b.Advance()
+	}
+	if got := slow.Drain(); len(got) != 3 || got[0] != 202 {
+		t.Fatalf("expected the last 3 retained events, got %v", got)
+	}
+	event.SetCapacity(b, event.Capacity[int]{Max: 1, Overflow: event.DropNewest})
+	w.Emit(300)
+	w.Emit(301)
+	b.Advance()
+	if got := slow.Drain(); len(got) != 1 || got[0] != 300 {
+		t.Fatalf("expected the overflow to be dropped, got %v", got)
+	}
+}
+
+func TestWaitCancelledAndComposition(t *testing.T) {
+	b := event.NewBus()
+	wi := event.WriterFor[int](b)
+	ws := event.WriterFor[string](b)
+	ri := event.ReaderFor[int](b)
+	// Idle readers keep both events pending until they are evicted.
+	_ = event.ReaderFor[int](b)
+	_ = event.ReaderFor[string](b)
+
+	cancelled := wi.EmitResult(1)
+	plain := ws.EmitResult("x")
+
+	var calls atomic.Int32
+	var gotCancelled atomic.Bool
+	cancelled.OnComplete(func(c bool) {
+		calls.Add(1)
+		gotCancelled.Store(c)
+	})
+
+	select {
+	case <-cancelled.Done():
+		t.Fatalf("Done closed before completion")
+	default:
+	}
+	idx, err := event.WaitAny(context.Background(), plain, cancelled, event.EventResult[int]{})
+	if err != nil || idx != 2 {
+		t.Fatalf("WaitAny with a zero result: got %d, %v; want 2, nil", idx, err)
+	}
+
+	b.Advance()
+
+	// WaitCancelled returns as soon as the reader cancels, before completion.
+	got := make(chan bool, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		got <- cancelled.WaitCancelled(ctx)
+	}()
+	ri.ForEach(func(int) bool {
+		ri.Cancel()
+		return true
+	})
+	select {
+	case c := <-got:
+		if !c {
+			t.Fatalf("WaitCancelled returned false")
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("WaitCancelled did not return on cancellation")
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("OnComplete ran before completion")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := event.WaitAll(ctx, cancelled, plain); err == nil {
+		t.Fatalf("WaitAll should time out before Advance")
+	}
+
+	b.Advance()
+	anyCancelled, err := event.WaitAll(context.Background(), cancelled, plain)
+	if err != nil || !anyCancelled {
+		t.Fatalf("WaitAll: got %v, %v; want true, nil", anyCancelled, err)
+	}
+	if calls.Load() != 1 || !gotCancelled.Load() {
+		t.Fatalf("OnComplete: calls=%d cancelled=%v", calls.Load(), gotCancelled.Load())
+	}
+	if plain.WaitCancelled(context.Background()) {
+		t.Fatalf("WaitCancelled on a completed, uncancelled event must be false")
+	}
+
+	// Registering after completion runs immediately.
+	cancelled.OnComplete(func(bool) { calls.Add(1) })
+	if calls.Load() != 2 {
+		t.Fatalf("late OnComplete did not run immediately")
+	}
+}
+
+type permission struct {
+	Action string
+}
+
+func TestRequestRepliesAndMerge(t *testing.T) {
+	b := event.NewBus()
+	event.SetRequestMerge[permission, int](b, event.Reduce(0, func(acc, v int) int { return acc + v }))
+	w := event.RequestWriterFor[permission, int](b)
+	r1 := event.RequestReaderFor[permission, int](b)
+	r2 := event.RequestReaderFor[permission, int](b)
+
+	sum := w.EmitRequest(permission{Action: "damage"})
+	last := w.WithMerge(event.Last[int]()).EmitRequest(permission{Action: "damage"})
+	none := w.EmitRequest(permission{Action: "ignored"})
+	b.Advance()
+
+	for _, r := range []*event.RequestReader[permission, int]{&r1, &r2} {
+		i := 0
+		r.ForEach(func(p permission) bool {
+			i++
+			if p.Action == "damage" {
+				r.Reply(i * 10)
+			}
+			return true
+		})
+	}
+	b.Advance()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if v, ok := sum.Await(ctx); !ok || v != 20 {
+		t.Fatalf("Reduce: got %v, %v; want 20, true", v, ok)
+	}
+	if v, ok := last.Await(ctx); !ok || v != 20 {
+		t.Fatalf("Last: got %v, %v; want 20, true", v, ok)
+	}
+	if all := sum.AwaitAll(ctx); len(all) != 2 {
+		t.Fatalf("AwaitAll: got %v, want 2 replies", all)
+	}
+	if _, ok := none.Await(ctx); ok {
+		t.Fatalf("expected no reply")
+	}
+
+	// First returns as soon as a reader replies, before completion.
+	wf := event.RequestWriterFor[permission, string](b)
+	rf := event.RequestReaderFor[permission, string](b)
+	_ = event.RequestReaderFor[permission, string](b) // not run yet
+	first := wf.EmitRequest(permission{Action: "chat"})
+	b.Advance()
+	rf.ForEach(func(permission) bool {
+		rf.Reply("muted")
+		return true
+	})
+	if v, ok := first.Await(ctx); !ok || v != "muted" {
+		t.Fatalf("First: got %q, %v", v, ok)
+	}
+	select {
+	case <-first.Done():
+		t.Fatalf("request should not be complete before every reader ran")
+	default:
+	}
+}
+
+func TestReaderRegistration(t *testing.T) {
+	b := event.NewBus()
+	w := event.WriterFor[string](b)
+	if event.HasReaders[string](b) {
+		t.Fatalf("HasReaders without readers")
+	}
+
+	// A declared reader keeps events pending until CompleteNoReader.
+	b.DeclareReader(reflect.TypeOf(""))
+	if !event.HasReaders[string](b) {
+		t.Fatalf("HasReaders should include declared readers")
+	}
+	declared := w.EmitResult("a")
+	b.Advance()
+	if isClosed(declared.Done()) {
+		t.Fatalf("event with a declared reader completed at emit")
+	}
+	b.CompleteNoReader()
+	if !isClosed(declared.Done()) {
+		t.Fatalf("CompleteNoReader did not complete the event")
+	}
+
+	// Events complete as soon as every registered reader processed them.
+	r1 := event.ReaderFor[string](b)
+	r2 := event.ReaderFor[string](b)
+	res := w.EmitResult("b")
+	b.Advance()
+	r1.ForEach(func(string) bool { return true })
+	if isClosed(res.Done()) {
+		t.Fatalf("completed before the second reader ran")
+	}
+	b.CompleteNoReader() // no effect while readers are registered
+	if isClosed(res.Done()) {
+		t.Fatalf("CompleteNoReader completed an event with pending readers")
+	}
+	r2.ForEach(func(string) bool { return true })
+	if !isClosed(res.Done()) {
+		t.Fatalf("not completed after all readers ran")
+	}
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestReaderPriorityOptions(t *testing.T) {
+	b := event.NewBus()
+	w := event.WriterFor[string](b)
+	low := event.ReaderWith[string](b, event.ReaderOptions{Priority: event.Lowest})
+	high := event.ReaderWith[string](b, event.ReaderOptions{Priority: event.High, IgnoreCancelled: true})
+	monitor := event.ReaderWith[string](b, event.ReaderOptions{Priority: event.Monitor})
+
+	muted := w.EmitResult("muted")
+	kept := w.EmitResult("kept")
+	b.Advance()
+
+	low.ForEach(func(s string) bool {
+		if s == "muted" {
+			low.Cancel()
+		}
+		return true
+	})
+	var seen []string
+	high.ForEach(func(s string) bool {
+		seen = append(seen, s)
+		return true
+	})
+	if len(seen) != 1 || seen[0] != "kept" {
+		t.Fatalf("IgnoreCancelled reader saw %v, want [kept]", seen)
+	}
+	monitor.ForEach(func(s string) bool {
+		if s == "muted" != monitor.IsCancelled() {
+			t.Fatalf("monitor saw wrong cancellation state for %q", s)
+		}
+		monitor.Cancel() // no-op for Monitor readers
+		return true
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if !muted.Wait(ctx) {
+		t.Fatalf("muted event should be cancelled")
+	}
+	if kept.Wait(ctx) {
+		t.Fatalf("monitor reader must not cancel")
+	}
+}
+
+func TestForEachMut(t *testing.T) {
+	b := event.NewBus()
+	w := event.WriterFor[testEvent](b)
+	first := event.ReaderWith[testEvent](b, event.ReaderOptions{Priority: event.Low})
+	second := event.ReaderFor[testEvent](b)
+
+	w.Emit(testEvent{ID: 1})
+	w.Emit(testEvent{ID: 2})
+	b.Advance()
+
+	first.ForEachMut(func(e *testEvent) bool {
+		e.ID *= 10
+		return true
+	})
+	got := collect(&second)
+	if len(got) != 2 || got[0].ID != 10 || got[1].ID != 20 {
+		t.Fatalf("later reader saw %v, want IDs [10 20]", got)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	b := event.NewBus()
+	w := event.WriterFor[int](b)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if event.HasReaders[int](b) {
+		t.Fatal("expected no readers before subscribing")
+	}
+	ch := event.Subscribe[int](ctx, b, event.SubscribeOptions{Buffer: 2, Overflow: event.DropNewest})
+	oldest := event.Subscribe[int](ctx, b, event.SubscribeOptions{Buffer: 2, Overflow: event.DropOldest})
+	if !event.HasReaders[int](b) {
+		t.Fatal("expected subscriptions to count for HasReaders")
+	}
+
+	// Subscribers see nothing before Advance and do not delay completion.
+	res := w.EmitResult(1)
+	w.Emit(2)
+	w.Emit(3)
+	select {
+	case v := <-ch:
+		t.Fatalf("received %d before Advance", v)
+	default:
+	}
+	select {
+	case <-res.Done():
+	default:
+		t.Fatal("expected the event to complete at emit without readers")
+	}
+	b.Advance()
+
+	recv := func(ch <-chan int, n int) []int {
+		var out []int
+		for range n {
+			select {
+			case v := <-ch:
+				out = append(out, v)
+			case <-time.After(time.Second):
+				t.Fatalf("timed out after %v", out)
+			}
+		}
+		return out
+	}
+	if got := recv(ch, 2); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("DropNewest got %v, want [1 2]", got)
+	}
+	if got := recv(oldest, 2); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Fatalf("DropOldest got %v, want [2 3]", got)
+	}
+
+	// Block waits for the subscriber instead of dropping.
+	blocking := event.Subscribe[int](ctx, b, event.SubscribeOptions{Buffer: 1})
+	w.Emit(4)
+	w.Emit(5)
+	advanced := make(chan struct{})
+	go func() {
+		b.Advance()
+		close(advanced)
+	}()
+	if got := recv(blocking, 2); !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Fatalf("Block got %v, want [4 5]", got)
+	}
+	<-advanced
+
+	// Cancelling the context closes the channel, even while Advance is blocked.
+	w.Emit(6)
+	w.Emit(7)
+	advanced = make(chan struct{})
+	go func() {
+		b.Advance()
+		close(advanced)
+	}()
+	cancel()
+	select {
+	case <-advanced:
+	case <-time.After(time.Second):
+		t.Fatal("Advance stayed blocked after cancellation")
+	}
+	for _, c := range []<-chan int{blocking, ch, oldest} {
+		for range c {
+		}
+	}
+	if event.HasReaders[int](b) {
+		t.Fatal("expected cancelled subscriptions to be removed")
+	}
+}
+
+func TestSubscribeSeq(t *testing.T) {
+	b := event.NewBus()
+	w := event.WriterFor[string](b)
+	seq := event.SubscribeSeq[string](context.Background(), b, event.SubscribeOptions{})
+	w.Emit("a")
+	w.Emit("b")
+	w.Emit("c")
+	b.Advance()
+
+	var got []string
+	for v := range seq {
+		got = append(got, v)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("got %v, want [a b]", got)
+	}
+	if event.HasReaders[string](b) {
+		t.Fatal("expected breaking out of the loop to end the subscription")
+	}
+}
+
+func TestEventCapacity(t *testing.T) {
+	isDone := func(r event.EventResult[int]) bool {
+		select {
+		case <-r.Done():
+			return true
+		default:
+			return false
+		}
+	}
+	// run emits vals and returns what a reader sees after Advance, and which
+	// events completed at emit time, i.e. were dropped.
+	run := func(c event.Capacity[int], vals ...int) ([]int, []bool, *missCounter) {
+		b := event.NewBus()
+		diag := &missCounter{}
+		b.SetDiagnostics(diag)
+		event.SetCapacity(b, c)
+		w := event.WriterFor[int](b)
+		r := event.ReaderFor[int](b)
+		var res []event.EventResult[int]
+		for _, v := range vals {
+			res = append(res, w.EmitResult(v))
+		}
+		done := make([]bool, len(res))
+		for i, r := range res {
+			done[i] = isDone(r)
+		}
+		b.Advance()
+		return collect(&r), done, diag
+	}
+
+	got, done, diag := run(event.Capacity[int]{Max: 2, Overflow: event.DropNewest}, 1, 2, 3)
+	if !reflect.DeepEqual(got, []int{1, 2}) || diag.dropped.Load() != 1 || !reflect.DeepEqual(done, []bool{false, false, true}) {
+		t.Fatalf("DropNewest: got %v, dropped %d", got, diag.dropped.Load())
+	}
+
+	got, done, diag = run(event.Capacity[int]{Max: 2, Overflow: event.DropOldest}, 1, 2, 3)
+	if !reflect.DeepEqual(got, []int{2, 3}) || diag.dropped.Load() != 1 || !reflect.DeepEqual(done, []bool{true, false, false}) {
+		t.Fatalf("DropOldest: got %v, dropped %d", got, diag.dropped.Load())
+	}
+
+	// Dropping past the buffer size keeps the newest writes in order.
+	got, _, diag = run(event.Capacity[int]{Max: 3, Overflow: event.DropOldest}, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	if !reflect.DeepEqual(got, []int{8, 9, 10}) || diag.dropped.Load() != 7 {
+		t.Fatalf("DropOldest over many writes: got %v, dropped %d", got, diag.dropped.Load())
+	}
+
+	sum := func(prev, next int) int { return prev + next }
+	got, done, diag = run(event.Capacity[int]{Max: 2, Overflow: event.Coalesce, Coalesce: sum}, 1, 2, 3, 4)
+	if !reflect.DeepEqual(got, []int{1, 9}) || diag.dropped.Load() != 0 || slices.Contains(done, true) {
+		t.Fatalf("Coalesce: got %v, dropped %d", got, diag.dropped.Load())
+	}
+
+	got, done, diag = run(event.Capacity[int]{Max: 1, Timeout: 10 * time.Millisecond}, 1, 2)
+	if !reflect.DeepEqual(got, []int{1}) || diag.dropped.Load() != 1 || diag.blocked.Load() != 1 || !done[1] {
+		t.Fatalf("Block with timeout: got %v, dropped %d, blocked %d", got, diag.dropped.Load(), diag.blocked.Load())
+	}
+
+	// Without a timeout, a blocked writer resumes once Advance makes room.
+	b := event.NewBus()
+	diag = &missCounter{}
+	b.SetDiagnostics(diag)
+	event.SetCapacity(b, event.Capacity[int]{Max: 1})
+	w := event.WriterFor[int](b)
+	r := event.ReaderFor[int](b)
+	w.Emit(1)
+	emitted := make(chan struct{})
+	go func() {
+		w.EmitMany([]int{2, 3})
+		close(emitted)
+	}()
+	var all []int
+	for len(all) < 3 {
+		b.Advance()
+		all = append(all, collect(&r)...)
+		time.Sleep(time.Millisecond)
+	}
+	<-emitted
+	if !reflect.DeepEqual(all, []int{1, 2, 3}) || diag.dropped.Load() != 0 || diag.blocked.Load() < 1 {
+		t.Fatalf("Block: got %v, dropped %d, blocked %d", all, diag.dropped.Load(), diag.blocked.Load())
+	}
+
+	// Full subscriptions report their drops too.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	event.Subscribe[int](ctx, b, event.SubscribeOptions{Buffer: 1, Overflow: event.DropNewest})
+	w.Emit(4)
+	b.Advance()
+	w.Emit(5)
+	b.Advance()
+	if diag.dropped.Load() != 1 {
+		t.Fatalf("expected 1 subscription drop, got %d", diag.dropped.Load())
+	}
+}
+
+func TestEmitKeyed(t *testing.T) {
+	type scoreUpdated struct {
+		Player string
+		Delta  int
+	}
+	b := event.NewBus()
+	event.SetKeyedMerge(b, func(prev, next scoreUpdated) scoreUpdated {
+		prev.Delta += next.Delta
+		return prev
+	})
+	w := event.WriterFor[scoreUpdated](b)
+	r := event.ReaderFor[scoreUpdated](b)
+
+	w.EmitKeyed(1, scoreUpdated{"a", 1})
+	w.EmitKeyed(2, scoreUpdated{"b", 1})
+	w.Emit(scoreUpdated{"c", 1})
+	w.EmitKeyed(1, scoreUpdated{"a", 2})
+	w.EmitKeyed(1, scoreUpdated{"a", 3})
+	b.Advance()
+	want := []scoreUpdated{{"a", 6}, {"b", 1}, {"c", 1}}
+	if got := collect(&r); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// Keys only collapse within a frame.
+	var got []scoreUpdated
+	for _, d := range []int{4, 5} {
+		w.EmitKeyed(1, scoreUpdated{"a", d})
+		b.Advance()
+		got = append(got, collect(&r)...)
+	}
+	if !reflect.DeepEqual(got, []scoreUpdated{{"a", 4}, {"a", 5}}) {
+		t.Fatalf("got %v, want separate events per frame", got)
+	}
+
+	// A key whose write was dropped to respect the capacity starts a new write.
+	event.SetCapacity(b, event.Capacity[scoreUpdated]{Max: 2, Overflow: event.DropOldest})
+	w.EmitKeyed(1, scoreUpdated{"a", 1})
+	w.EmitKeyed(2, scoreUpdated{"b", 1})
+	w.EmitKeyed(3, scoreUpdated{"c", 1})
+	w.EmitKeyed(1, scoreUpdated{"a", 2})
+	w.EmitKeyed(3, scoreUpdated{"c", 2})
+	b.Advance()
+	if got, want := collect(&r), []scoreUpdated{{"c", 3}, {"a", 2}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	event.SetCapacity(b, event.Capacity[scoreUpdated]{})
+
+	// Merging into a pending entry does not allocate.
+	w.EmitKeyed(7, scoreUpdated{"d", 1})
+	if n := testing.AllocsPerRun(100, func() {
+		w.EmitKeyed(7, scoreUpdated{"d", 1})
+	}); n != 0 {
+		t.Fatalf("expected no allocations when merging, got %v", n)
+	}
+	b.Advance()
+	// AllocsPerRun calls the function once more to warm up.
+	if got := collect(&r); len(got) != 1 || got[0].Delta != 102 {
+		t.Fatalf("got %v, want one merged event", got)
+	}
+}
+
+func TestEmitScheduled(t *testing.T) {
+	b := event.NewBus()
+	now := time.Unix(1000, 0)
+	b.SetClock(func() time.Time { return now })
+	w := event.WriterFor[testEvent](b)
+	r := event.ReaderFor[testEvent](b)
+
+	// Frame-scheduled events enter the write buffer when their frame starts
+	// and are readable from the next one.
+	w.EmitAtFrame(2, testEvent{ID: 2})
+	w.EmitAtFrame(1, testEvent{ID: 1})
+	cancelled := w.EmitAtFrame(2, testEvent{ID: -1})
+	far := w.EmitAtFrame(300, testEvent{ID: 300})
+	if !cancelled.Cancel() || cancelled.Pending() || cancelled.Cancel() {
+		t.Fatal("expected the first Cancel to succeed and the handle to stop pending")
+	}
+	var got []int
+	for b.Frame() < 3 {
+		b.Advance()
+		for _, e := range collect(&r) {
+			got = append(got, e.ID)
+		}
+	}
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+
+	// A frame that already started emits right away.
+	if s := w.EmitAtFrame(b.Frame(), testEvent{ID: 3}); s.Pending() {
+		t.Fatal("expected an immediate emit for the current frame")
+	}
+	b.Advance()
+	if got := collect(&r); len(got) != 1 || got[0].ID != 3 {
+		t.Fatalf("got %v, want the immediate event", got)
+	}
+
+	// Entries more than one revolution ahead wait for their frame.
+	for b.Frame() < 299 {
+		b.Advance()
+	}
+	if !far.Pending() || len(collect(&r)) != 0 {
+		t.Fatal("expected the frame 300 event to stay pending")
+	}
+	b.Advance()
+	b.Advance()
+	if got := collect(&r); far.Pending() || len(got) != 1 || got[0].ID != 300 {
+		t.Fatalf("got %v, want the frame 300 event", got)
+	}
+
+	// Time-scheduled events are released by the first Advance at their
+	// deadline, in deadline order.
+	w.EmitAfter(200*time.Millisecond, testEvent{ID: 20})
+	w.EmitAfter(100*time.Millisecond, testEvent{ID: 10})
+	respawn := w.EmitAfter(time.Second, testEvent{ID: 99})
+	got = got[:0]
+	for range 4 {
+		now = now.Add(60 * time.Millisecond)
+		b.Advance()
+		for _, e := range collect(&r) {
+			got = append(got, e.ID)
+		}
+	}
+	b.Advance()
+	for _, e := range collect(&r) {
+		got = append(got, e.ID)
+	}
+	if !reflect.DeepEqual(got, []int{10, 20}) {
+		t.Fatalf("got %v, want [10 20]", got)
+	}
+	if !respawn.Pending() || !respawn.Cancel() {
+		t.Fatal("expected the pending delivery to be cancellable")
+	}
+	now = now.Add(time.Hour)
+	b.Advance()
+	b.Advance()
+	if got := collect(&r); len(got) != 0 {
+		t.Fatalf("got %v, want no cancelled delivery", got)
+	}
+
+	// A clock jump of more than one wheel revolution still releases events in
+	// deadline order, and in emission order for equal deadlines.
+	// 556ms shares the 300ms slot one revolution later.
+	for _, e := range []struct {
+		ms, id int
+	}{{556, 556}, {300, 301}, {50, 50}, {300, 302}, {100, 100}, {40, 40}} {
+		w.EmitAfter(time.Duration(e.ms)*time.Millisecond, testEvent{ID: e.id})
+	}
+	now = now.Add(600 * time.Millisecond)
+	b.Advance()
+	b.Advance()
+	got = got[:0]
+	for _, e := range collect(&r) {
+		got = append(got, e.ID)
+	}
+	if want := []int{40, 50, 100, 301, 302, 556}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	var zero event.Scheduled
+	if zero.Pending() || zero.Cancel() {
+		t.Fatal("expected a zero handle to be inert")
+	}
+}
+
+type playerEvent interface{ player() string }
+
+type playerJoin struct{ Name string }
+
+func (e playerJoin) player() string { return e.Name }
+
+type playerChat struct{ Name, Msg string }
+
+func (e playerChat) player() string { return e.Name }
+
+type playerQuit struct{ Name string }
+
+func (e *playerQuit) player() string { return e.Name }
+
+func TestInterfaceReader(t *testing.T) {
+	b := event.NewBus()
+	join := event.WriterFor[playerJoin](b)
+	chat := event.WriterFor[playerChat](b)
+	event.WriterFor[testEvent](b)
+	r := event.ReaderFor[playerEvent](b)
+
+	chat.Emit(playerChat{"b", "hi"})
+	join.Emit(playerJoin{"a"})
+	chat.Emit(playerChat{"a", "yo"})
+	res := join.EmitResult(playerJoin{"c"})
+	b.Advance()
+
+	// Types are read in name order, each in emission order.
+	var got []playerEvent
+	r.ForEach(func(e playerEvent) bool {
+		got = append(got, e)
+		if j, ok := e.(playerJoin); ok && j.Name == "c" {
+			r.Cancel()
+		}
+		return true
+	})
+	want := []playerEvent{playerChat{"b", "hi"}, playerChat{"a", "yo"}, playerJoin{"a"}, playerJoin{"c"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if !res.Cancelled() {
+		t.Fatal("expected Cancel to reach the concrete event")
+	}
+
+	// Types stored after the reader was created are picked up, and the reader
+	// counts towards their completion.
+	quit := event.WriterFor[*playerQuit](b)
+	r.ForEach(func(playerEvent) bool { return true })
+	done := quit.EmitResult(&playerQuit{"a"})
+	b.Advance()
+	b.CompleteNoReader()
+	select {
+	case <-done.Done():
+		t.Fatal("expected the interface reader to hold completion")
+	default:
+	}
+	if vals := r.Drain(); len(vals) != 1 || vals[0].player() != "a" {
+		t.Fatalf("got %v, want the quit event", vals)
+	}
+
+	names := make([]string, 0, 4)
+	for _, typ := range b.Types() {
+		names = append(names, typ.String())
+	}
+	if !slices.IsSorted(names) || len(names) != 4 {
+		t.Fatalf("expected 4 sorted types, got %v", names)
+	}
+}
+
+func TestDeclareInterfaceReader(t *testing.T) {
+	b := event.NewBus()
+	event.WriterFor[playerJoin](b)
+	b.DeclareReader(reflect.TypeOf((*playerEvent)(nil)).Elem())
+	if !event.HasReaders[playerJoin](b) || !event.HasReaders[playerChat](b) {
+		t.Fatal("expected existing and later implementers to be declared")
+	}
+	if event.HasReaders[testEvent](b) {
+		t.Fatal("expected other types to stay undeclared")
+	}
+}
+
+func TestShardedEmitOrder(t *testing.T) {
+	// Force several write shards even on single-CPU machines.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	b := event.NewBus()
+	w := event.WriterFor[testEvent](b)
+	r := event.ReaderFor[testEvent](b)
+
+	// A single goroutine's events keep their order across shards, including
+	// keyed and bulk writes, which take the store lock.
+	w.Emit(testEvent{ID: 1})
+	w.EmitKeyed("k", testEvent{ID: 2})
+	w.EmitMany([]testEvent{{ID: 3}, {ID: 4}})
+	w.Emit(testEvent{ID: 5})
+	w.EmitKeyed("k", testEvent{ID: 2})
+	for i := 6; i < 100; i++ {
+		w.Emit(testEvent{ID: i})
+	}
+	b.Advance()
+	got := collect(&r)
+	if len(got) != 99 || !slices.IsSortedFunc(got, func(a, b testEvent) int { return a.ID - b.ID }) {
+		t.Fatalf("expected 99 events in emission order, got %v", got)
+	}
+
+	// Concurrent writers each keep their own order.
+	const writers, per = 8, 500
+	var wg sync.WaitGroup
+	for g := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range per {
+				w.Emit(testEvent{ID: g*per + i})
+			}
+		}()
+	}
+	wg.Wait()
+	b.Advance()
+	last := make([]int, writers)
+	for i := range last {
+		last[i] = -1
+	}
+	n := 0
+	r.ForEach(func(e testEvent) bool {
+		g := e.ID / per
+		if e.ID <= last[g] {
+			t.Fatalf("writer %d: event %d after %d", g, e.ID, last[g])
+		}
+		last[g] = e.ID
+		n++
+		return true
+	})
+	if n != writers*per {
+		t.Fatalf("got %d events, want %d", n, writers*per)
+	}
+}
+
+func TestJournalReplay(t *testing.T) {
+	for _, codec := range []event.Codec{event.Gob, event.JSON} {
+		// Record three frames, the second one empty.
+		var buf bytes.Buffer
+		b := event.NewBus()
+		b.Advance() // recording frames are relative to its start
+		w := event.WriterFor[testEvent](b)
+		wc := event.WriterFor[cancelEvent](b)
+		j := b.Record(&buf, codec)
+		w.Emit(testEvent{ID: 1})
+		wc.Emit(cancelEvent{Msg: "a"})
+		w.Emit(testEvent{ID: 2})
+		b.Advance()
+		b.Advance()
+		w.EmitKeyed("k", testEvent{ID: 3})
+		b.Advance()
+		if err := j.Close(); err != nil {
+			t.Fatal(err)
+		}
+		w.Emit(testEvent{ID: 99}) // after Close, not recorded
+		b.Advance()
+
+		// Replay into a fresh bus whose live writes are ignored meanwhile.
+		b = event.NewBus()
+		r := event.ReaderFor[testEvent](b)
+		rc := event.ReaderFor[cancelEvent](b)
+		w = event.WriterFor[testEvent](b)
+		p, err := b.Replay(&buf, codec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Emit(testEvent{ID: 100})
+		want := [][]testEvent{{{ID: 1}, {ID: 2}}, nil, {{ID: 3}}}
+		for i, frame := range want {
+			b.Advance()
+			if got := collect(&r); !slices.Equal(got, frame) {
+				t.Fatalf("%T: frame %d: got %v, want %v", codec, i, got, frame)
+			}
+			if got := collect(&rc); i == 0 && !slices.Equal(got, []cancelEvent{{Msg: "a"}}) {
+				t.Fatalf("%T: got %v", codec, got)
+			}
+		}
+		if !p.Done() || p.Err() != nil {
+			t.Fatalf("%T: replay should be done without error, got %v", codec, p.Err())
+		}
+
+		// Live writes resume once the journal is exhausted.
+		w.Emit(testEvent{ID: 4})
+		b.Advance()
+		if got := collect(&r); !slices.Equal(got, []testEvent{{ID: 4}}) {
+			t.Fatalf("%T: got %v after replay", codec, got)
+		}
+	}
+}
+
+func TestJournalUnknownType(t *testing.T) {
+	var buf bytes.Buffer
+	b := event.NewBus()
+	w := event.WriterFor[testEvent](b)
+	j := b.Record(&buf, event.JSON)
+	w.Emit(testEvent{ID: 1})
+	b.Advance()
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := event.NewBus().Replay(bytes.NewReader(buf.Bytes()), event.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Done() || p.Err() == nil {
+		t.Fatal("replaying an unknown type should fail")
+	}
+
+	b = event.NewBus()
+	event.RegisterType[testEvent](b)
+	p, err = b.Replay(bytes.NewReader(buf.Bytes()), event.JSON)
+	if err != nil || p.Err() != nil {
+		t.Fatalf("registered type should replay, got %v, %v", err, p.Err())
+	}
+	if _, err := b.Replay(bytes.NewReader([]byte("{}")), event.JSON); err == nil {
+		t.Fatal("expected a version error")
+	}
+}
+
+func TestStats(t *testing.T) {
+	b := event.NewBus()
+	w := event.WriterFor[testEvent](b)
+	r := event.ReaderFor[testEvent](b)
+	event.WriterFor[cancelEvent](b).Emit(cancelEvent{Msg: "nobody listens"})
+
+	w.Emit(testEvent{ID: 1})
+	res := w.EmitResult(testEvent{ID: 2})
+	stats := b.Stats()
+	if len(stats) != 2 || stats[0].Type != reflect.TypeFor[cancelEvent]() {
+		t.Fatalf("expected stats sorted by type name, got %+v", stats)
+	}
+	if !stats[0].Unread() || stats[1].Unread() {
+		t.Fatalf("only cancelEvent should be unread, got %+v", stats)
+	}
+	if s := stats[1]; s.Pending != 2 || s.Waiters != 1 || s.Readers != 1 || s.Published != 0 {
+		t.Fatalf("unexpected stats before Advance: %+v", s)
+	}
+
+	b.Advance()
+	r.ForEach(func(e testEvent) bool {
+		if e.ID == 2 {
+			r.Cancel()
+			r.Cancel() // counted once
+		}
+		return true
+	})
+	s := b.Stats()[1]
+	if s.Pending != 0 || s.Retained != 2 || s.Published != 2 || s.Cancelled != 1 || s.LastEmit != 0 {
+		t.Fatalf("unexpected stats after Advance: %+v", s)
+	}
+	if !res.Cancelled() || s.Waiters != 0 {
+		t.Fatalf("the result should be cancelled and complete, got %+v", s)
+	}
+
+	b.Advance()
+	w.Emit(testEvent{ID: 3})
+	if s := b.Stats()[1]; s.LastEmit != 2 || s.Retained != 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestLink(t *testing.T) {
+	for _, codec := range []event.Codec{event.Gob, event.JSON} {
+		lobby, match := event.NewBus(), event.NewBus()
+		a, b := event.Loopback(lobby, match, codec)
+		event.Send[testEvent](a, event.SendOptions{Results: true})
+		event.Receive[testEvent](b)
+		event.Send[cancelEvent](b, event.SendOptions{})
+		event.Receive[cancelEvent](a)
+		a.Start()
+		b.Start()
+
+		r := event.ReaderFor[testEvent](match)
+		rc := event.ReaderFor[cancelEvent](lobby)
+		w := event.WriterFor[testEvent](lobby)
+		w.Emit(testEvent{ID: 1})
+		res := w.EmitResult(testEvent{ID: 2})
+		if !event.HasReaders[testEvent](lobby) {
+			t.Fatal("a link forwarding results should count as a reader")
+		}
+		lobby.Advance()
+		lobby.Advance() // evicts the local copy
+		select {
+		case <-res.Done():
+			t.Fatalf("%T: result completed before the peer replied", codec)
+		default:
+		}
+
+		// Forwarded events enter the remote write buffer and are published
+		// by the remote Advance.
+		var got []testEvent
+		for deadline := time.Now().Add(5 * time.Second); len(got) < 2; {
+			if time.Now().After(deadline) {
+				t.Fatalf("%T: timed out, got %v", codec, got)
+			}
+			match.Advance()
+			r.ForEach(func(e testEvent) bool {
+				got = append(got, e)
+				if e.ID == 2 {
+					r.Cancel()
+				}
+				return true
+			})
+			time.Sleep(time.Millisecond)
+		}
+		if !slices.Equal(got, []testEvent{{ID: 1}, {ID: 2}}) {
+			t.Fatalf("%T: got %v", codec, got)
+		}
+
+		// The local result completes, cancelled, once the remote one has.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if !res.Wait(ctx) {
+			t.Fatalf("%T: expected the remote cancellation", codec)
+		}
+		cancel()
+
+		// The other direction, fire-and-forget.
+		event.WriterFor[cancelEvent](match).Emit(cancelEvent{Msg: "hi"})
+		match.Advance()
+		var msgs []cancelEvent
+		for deadline := time.Now().Add(5 * time.Second); len(msgs) == 0; {
+			if time.Now().After(deadline) {
+				t.Fatalf("%T: timed out", codec)
+			}
+			lobby.Advance()
+			msgs = append(msgs, collect(&rc)...)
+			time.Sleep(time.Millisecond)
+		}
+		if msgs[0].Msg != "hi" {
+			t.Fatalf("%T: got %v", codec, msgs)
+		}
+
+		// Closing releases pending results and ends the peer.
+		pending := w.EmitResult(testEvent{ID: 3})
+		lobby.Advance()
+		if err := a.Close(); err != nil {
+			t.Fatal(err)
+		}
+		lobby.Advance()
+		select {
+		case <-pending.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%T: pending result not released by Close", codec)
+		}
+		select {
+		case <-b.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%T: peer did not notice Close", codec)
+		}
+		if b.Err() != nil {
+			t.Fatalf("%T: unexpected error %v", codec, b.Err())
+		}
+	}
+}
+
+func TestLinkUnexpectedType(t *testing.T) {
+	bus := event.NewBus()
+	a, b := event.Loopback(bus, event.NewBus(), nil)
+	event.Send[testEvent](a, event.SendOptions{})
+	a.Start()
+	b.Start()
+	event.WriterFor[testEvent](bus).Emit(testEvent{ID: 1})
+	bus.Advance()
+	select {
+	case <-b.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+	if b.Err() == nil {
+		t.Fatal("expected an error for a type that is not received")
+	}
+}
+
+func TestLinkTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+	server, client := event.NewBus(), event.NewBus()
+	accepted := make(chan *event.Link, 1)
+	go func() {
+		l, err := event.Accept(ln, server, event.JSON)
+		if err != nil {
+			t.Error(err)
+		}
+		event.Receive[testEvent](l)
+		l.Start()
+		accepted <- l
+	}()
+	c, err := event.Dial(context.Background(), "tcp", ln.Addr().String(), client, event.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	event.Send[testEvent](c, event.SendOptions{})
+	c.Start()
+	s := <-accepted
+	defer s.Close()
+
+	r := event.ReaderFor[testEvent](server)
+	event.WriterFor[testEvent](client).Emit(testEvent{ID: 7})
+	client.Advance()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		server.Advance()
+		if got := collect(&r); len(got) > 0 {
+			if got[0].ID != 7 {
+				t.Fatalf("got %v", got)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+	}
+}
+
+func TestPark(t *testing.T) {
+	b := event.NewBus()
+	w := event.WriterFor[testEvent](b)
+	wake, ok := b.Park()
+	if !ok {
+		t.Fatal("an empty bus should park")
+	}
+	go w.Emit(testEvent{ID: 1})
+	select {
+	case <-wake:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a write should wake the parked bus")
+	}
+	if _, ok := b.Park(); ok {
+		t.Fatal("pending writes should prevent parking")
+	}
+	b.Advance()
+
+	if _, ok := b.Park(); ok {
+		t.Fatal("freshly published events should prevent parking")
+	}
+
+	// Writes while not parked do not signal; Wake does, even before Park.
+	w.Emit(testEvent{ID: 2})
+	b.Advance()
+	b.Advance()
+	b.Wake()
+	wake, ok = b.Park()
+	if !ok {
+		t.Fatal("expected to park")
+	}
+	select {
+	case <-wake:
+	default:
+		t.Fatal("an earlier Wake should signal the next Park")
+	}
+	b.Unpark()
+
+	// Scheduled events: frames keep the bus busy, delays set a deadline.
+	now := time.UnixMilli(5000)
+	b.SetClock(func() time.Time { return now })
+	s := w.EmitAtFrame(b.Frame()+10, testEvent{ID: 3})
+	if _, ok := b.Park(); ok {
+		t.Fatal("a pending EmitAtFrame should prevent parking")
+	}
+	s.Cancel()
+	w.EmitAfter(time.Second, testEvent{ID: 4})
+	w.EmitAfter(300*time.Millisecond, testEvent{ID: 5}).Cancel()
+	if _, ok := b.Park(); !ok {
+		t.Fatal("expected to park")
+	}
+	b.Unpark()
+	if at, ok := b.NextRelease(); !ok || !at.Equal(now.Add(time.Second)) {
+		t.Fatalf("expected the next release in a second, got %v %v", at, ok)
+	}
+}
+
+func TestStream(t *testing.T) {
+	b := event.NewBus()
+	s := event.StreamFor(b, event.Of[testEvent](), event.Of[cancelEvent]())
+	r := event.ReaderFor[testEvent](b)
+	w := event.WriterFor[testEvent](b)
+	wc := event.WriterFor[cancelEvent](b)
+
+	w.Emit(testEvent{ID: 1})
+	wc.Emit(cancelEvent{Msg: "a"})
+	w.EmitMany([]testEvent{{ID: 2}, {ID: 3}})
+	res := wc.EmitResult(cancelEvent{Msg: "b"})
+	w.Emit(testEvent{ID: 4})
+	b.Advance()
+	wc.Emit(cancelEvent{Msg: "c"}) // next frame
+
+	want := []any{testEvent{ID: 1}, cancelEvent{Msg: "a"}, testEvent{ID: 2}, testEvent{ID: 3}, cancelEvent{Msg: "b"}, testEvent{ID: 4}}
+	var got []any
+	var last uint64
+	s.ForEach(func(v any) bool {
+		if s.Seq() <= last {
+			t.Fatalf("sequence numbers should increase, got %d after %d", s.Seq(), last)
+		}
+		last = s.Seq()
+		if v == (cancelEvent{Msg: "b"}) {
+			s.Cancel()
+		}
+		got = append(got, v)
+		return len(got) < 3 // stop early, resume below
+	})
+	s.ForEach(func(v any) bool {
+		if v == (cancelEvent{Msg: "b"}) {
+			s.Cancel()
+		}
+		got = append(got, v)
+		return true
+	})
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected emission order %v, got %v", want, got)
+	}
+	if !res.Cancelled() {
+		t.Fatal("cancelling through the stream should reach the writer")
+	}
+
+	// Plain readers of sequenced types see the same numbers.
+	var seqs []uint64
+	r.ForEach(func(testEvent) bool {
+		seqs = append(seqs, r.Seq())
+		return true
+	})
+	if len(seqs) != 4 || !slices.IsSorted(seqs) || seqs[0] == 0 {
+		t.Fatalf("unexpected sequence numbers %v", seqs)
+	}
+	if r.Seq() != 0 {
+		t.Fatal("Seq should be 0 outside ForEach")
+	}
+
+	b.Advance()
+	if got := s.Drain(); !slices.Equal(got, []any{cancelEvent{Msg: "c"}}) {
+		t.Fatalf("got %v", got)
+	}
+	if got := s.Drain(); len(got) != 0 {
+		t.Fatalf("drained events should be read, got %v", got)
+	}
+
+	// Unsequenced types report 0.
+	ru := event.ReaderFor[int](b)
+	event.WriterFor[int](b).Emit(1)
+	b.Advance()
+	ru.ForEach(func(int) bool {
+		if ru.Seq() != 0 {
+			t.Fatalf("unsequenced type should have no sequence number, got %d", ru.Seq())
+		}
+		return true
+	})
+}
+
+// BenchmarkEmitParallel emits from concurrent goroutines through one shared
+// writer, as the Dragonfly bridge does from player goroutines.
+func BenchmarkEmitParallel(b *testing.B) {
+	bus := event.NewBus()
+	w := event.WriterFor[testEvent](bus)
+	event.ReaderFor[testEvent](bus)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			w.Emit(testEvent{ID: i})
+			if i++; i%4096 == 0 {
+				bus.Advance()
+			}
+		}
+	})
+}
+
+// BenchmarkEmitParallelWriters emits from concurrent goroutines, each with
+// its own writer.
+func BenchmarkEmitParallelWriters(b *testing.B) {
+	bus := event.NewBus()
+	event.ReaderFor[testEvent](bus)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		w := event.WriterFor[testEvent](bus)
+		i := 0
+		for pb.Next() {
+			w.Emit(testEvent{ID: i})
+			if i++; i%4096 == 0 {
+				bus.Advance()
+			}
+		}
+	})
+}
+
+// BenchmarkEmitAdvance measures a single-goroutine frame of 1024 events,
+// including the merge at Advance.
+func BenchmarkEmitAdvance(b *testing.B) {
+	bus := event.NewBus()
+	w := event.WriterFor[testEvent](bus)
+	r := event.ReaderFor[testEvent](bus)
+	b.ReportAllocs()
+	for b.Loop() {
+		for i := range 1024 {
+			w.Emit(testEvent{ID: i})
+		}
+		bus.Advance()
+		r.ForEach(func(testEvent) bool { return true })
+	}
+}
+
+// BenchmarkEmit measures fire-and-forget emits, read once per frame of 1024.
+func BenchmarkEmit(b *testing.B) {
+	bus := event.NewBus()
+	w := event.WriterFor[testEvent](bus)
+	r := event.ReaderFor[testEvent](bus)
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		w.Emit(testEvent{ID: i})
+		if i%1024 == 1023 {
+			bus.Advance()
+			r.ForEach(func(testEvent) bool { return true })
+		}
+	}
+}
+
+// BenchmarkEmitMany measures bulk emits of 64 events per call.
+func BenchmarkEmitMany(b *testing.B) {
+	bus := event.NewBus()
+	w := event.WriterFor[testEvent](bus)
+	r := event.ReaderFor[testEvent](bus)
+	batch := make([]testEvent, 64)
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		w.EmitMany(batch)
+		if i%16 == 15 {
+			bus.Advance()
+			r.ForEach(func(testEvent) bool { return true })
+		}
+	}
+}
+
+// BenchmarkEmitResult measures emits that return a completion handle.
+func BenchmarkEmitResult(b *testing.B) {
+	bus := event.NewBus()
+	w := event.WriterFor[testEvent](bus)
+	r := event.ReaderFor[testEvent](bus)
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		w.EmitResult(testEvent{ID: i})
+		if i%1024 == 1023 {
+			bus.Advance()
+			r.ForEach(func(testEvent) bool { return true })
+		}
+	}
+}
+
+// BenchmarkRetainedBytes reports the heap held per retained fire-and-forget
+// event of a 64K-event frame.
+func BenchmarkRetainedBytes(b *testing.B) {
+	const n = 1 << 16
+	var ms runtime.MemStats
+	var total int64
+	for b.Loop() {
+		bus := event.NewBus()
+		w := event.WriterFor[testEvent](bus)
+		event.ReaderFor[testEvent](bus)
+		runtime.GC()
+		runtime.ReadMemStats(&ms)
+		before := int64(ms.HeapAlloc)
+		for i := range n {
+			w.Emit(testEvent{ID: i})
+		}
+		bus.Advance()
+		runtime.GC()
+		runtime.ReadMemStats(&ms)
+		total += int64(ms.HeapAlloc) - before
+		runtime.KeepAlive(bus)
+	}
+	b.ReportMetric(float64(total)/float64(b.N)/n, "B/event")
+}
+
+// Test that closing a reader releases the events it has not read, stops
+// counting it for later ones and leaves it, and its copies, reading nothing.
+func TestReaderClose(t *testing.T) {
+	b := event.NewBus()
+	w := event.WriterFor[int](b)
+	active := event.ReaderFor[int](b)
+	idle := event.ReaderFor[int](b)
+	cp := idle
+
+	res := w.EmitResult(1)
+	b.Advance()
+	collect(&active)
+	if isClosed(res.Done()) {
+		t.Fatal("expected the idle reader to hold the event")
+	}
+	idle.Close()
+	if !isClosed(res.Done()) {
+		t.Fatal("expected Close to release the unread event")
+	}
+	idle.Close() // no-op
+
+	res = w.EmitResult(2)
+	b.Advance()
+	if got := collect(&cp); len(got) != 0 {
+		t.Fatalf("expected a copy of a closed reader to read nothing, got %v", got)
+	}
+	collect(&active)
+	if !isClosed(res.Done()) {
+		t.Fatal("expected a closed reader not to count for later events")
+	}
+	if st := b.Stats(); len(st) != 1 || st[0].Readers != 1 {
+		t.Fatalf("expected 1 registered reader, got %+v", st)
+	}
+
+	// Interface readers close their reader of every implementing type.
+	join := event.WriterFor[playerJoin](b)
+	r := event.ReaderFor[playerEvent](b)
+	res2 := join.EmitResult(playerJoin{"a"})
+	b.Advance()
+	r.Close()
+	if !isClosed(res2.Done()) {
+		t.Fatal("expected Close to release the interface reader's events")
+	}
+}
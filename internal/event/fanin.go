@@ -0,0 +1,142 @@
+package event
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// fanner is implemented by every store to let interface readers discover the
+// stores whose event type implements their interface.
+type fanner interface {
+	elemType() reflect.Type
+	fanSource(opts ReaderOptions) fanSource
+}
+
+// fanSource is a per-type reader seen through an interface reader.
+type fanSource interface {
+	eachAny(yield func(any) bool)
+	drainAny(limit int) []any
+	Cancel()
+	IsCancelled() bool
+	Seq() uint64
+	Close()
+}
+
+func (s *store[T]) elemType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (s *store[T]) fanSource(opts ReaderOptions) fanSource {
+	return &Reader[T]{store: s, state: s.newReader(), opts: opts}
+}
+
+func (r *Reader[T]) eachAny(yield func(any) bool) {
+	r.each(func(v *T) bool { return yield(*v) })
+}
+
+func (r *Reader[T]) drainAny(limit int) []any {
+	vals := r.store.drain(r.state, limit)
+	out := make([]any, len(vals))
+	for i, v := range vals {
+		out[i] = v
+	}
+	return out
+}
+
+// fanIn reads every event type implementing the interface I. It holds one
+// registered reader per matching store, ordered by type name, and picks up
+// stores created after it the next time it is read.
+type fanIn[I any] struct {
+	bus   *Bus
+	iface reflect.Type
+	opts  ReaderOptions
+	gen   uint64
+	types []reflect.Type
+	srcs  []fanSource
+	cur   fanSource // source of the current event for Cancel()/IsCancelled()
+	done  bool      // closed; no longer picks up new stores
+}
+
+func newFanIn[I any](b *Bus, opts ReaderOptions) *fanIn[I] {
+	f := &fanIn[I]{bus: b, iface: reflect.TypeFor[I](), opts: opts}
+	f.refresh()
+	return f
+}
+
+// refresh adds the stores created since the previous refresh.
+func (f *fanIn[I]) refresh() {
+	if f.done {
+		return
+	}
+	gen := f.bus.gen.Load()
+	if gen == f.gen && f.gen != 0 {
+		return
+	}
+	f.gen = gen
+	f.bus.stores.Range(func(_, v any) bool {
+		fn, ok := v.(fanner)
+		if !ok {
+			return true
+		}
+		t := fn.elemType()
+		if !t.Implements(f.iface) || slices.Contains(f.types, t) {
+			return true
+		}
+		i, _ := slices.BinarySearchFunc(f.types, t, compareTypes)
+		f.types = slices.Insert(f.types, i, t)
+		f.srcs = slices.Insert(f.srcs, i, fn.fanSource(f.opts))
+		return true
+	})
+}
+
+func (f *fanIn[I]) each(yield func(*I) bool) {
+	f.refresh()
+	for _, src := range f.srcs {
+		f.cur = src
+		ok := true
+		src.eachAny(func(v any) bool {
+			ev := v.(I)
+			ok = yield(&ev)
+			return ok
+		})
+		if !ok {
+			break
+		}
+	}
+	f.cur = nil
+}
+
+func (f *fanIn[I]) drain(limit int) []I {
+	f.refresh()
+	var out []I
+	for _, src := range f.srcs {
+		if limit >= 0 && len(out) >= limit {
+			break
+		}
+		left := -1
+		if limit >= 0 {
+			left = limit - len(out)
+		}
+		for _, v := range src.drainAny(left) {
+			out = append(out, v.(I))
+		}
+	}
+	return out
+}
+
+// close closes the reader of every matching store.
+func (f *fanIn[I]) close() {
+	f.done = true
+	for _, src := range f.srcs {
+		src.Close()
+	}
+}
+
+// compareTypes orders types by name, then package path.
+func compareTypes(a, b reflect.Type) int {
+	if c := strings.Compare(a.String(), b.String()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.PkgPath(), b.PkgPath())
+}
@@ -0,0 +1,96 @@
+package event
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// wakeup lets a parked App sleep until the next write. Writers only touch
+// the channel while the bus is parked, so the write path pays a single
+// atomic load.
+type wakeup struct {
+	parked atomic.Bool
+	ch     chan struct{}
+}
+
+// notify wakes the parked App, if any. Writers call it after appending.
+func (w *wakeup) notify() {
+	if w.parked.Load() && w.parked.CompareAndSwap(true, false) {
+		w.signal()
+	}
+}
+
+func (w *wakeup) signal() {
+	select {
+	case w.ch <- struct{}{}:
+	default:
+	}
+}
+
+// pender is implemented by the per-type store to report pending writes.
+type pender interface{ pending() bool }
+
+func (s *store[T]) pending() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.queue()) > 0 {
+		return true
+	}
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		n := len(sh.writes)
+		sh.mu.Unlock()
+		if n > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Park prepares the caller to sleep until there is something to advance. It
+// returns a channel signalled by the next event written, or by Wake, and
+// true. If events are already pending, the latest Advance published events
+// that readers have yet to see, an EmitAtFrame event waits for a later frame
+// or a journal is being replayed, it returns false and the caller should
+// run another frame right away.
+// Call Unpark once done waiting.
+func (b *Bus) Park() (<-chan struct{}, bool) {
+	b.wakeup.parked.Store(true)
+	pending := b.published.Load()
+	b.stores.Range(func(_, v any) bool {
+		if p, ok := v.(pender); ok && p.pending() {
+			pending = true
+		}
+		return !pending
+	})
+	if pending || b.timers.pendingFrames() || b.replay.Load() != nil {
+		b.wakeup.parked.Store(false)
+		return nil, false
+	}
+	return b.wakeup.ch, true
+}
+
+// Unpark ends a Park without a write, e.g. when a deadline passed.
+func (b *Bus) Unpark() {
+	b.wakeup.parked.Store(false)
+}
+
+// Wake signals the channel returned by Park. If the bus is not parked, the
+// next Park returns an already signalled channel.
+func (b *Bus) Wake() {
+	b.wakeup.parked.Store(false)
+	b.wakeup.signal()
+}
+
+// NextRelease returns the earliest time an event scheduled with EmitAfter is
+// due, or false if none is pending.
+func (b *Bus) NextRelease() (time.Time, bool) {
+	b.timers.mu.Lock()
+	defer b.timers.mu.Unlock()
+	tick, ok := b.timers.times.next()
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(int64(tick)), true
+}
@@ -0,0 +1,329 @@
+package event
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Codec encodes event journals and the messages of a Link. Encoders and
+// decoders are used as streams, one value per call, like gob and json do.
+type Codec interface {
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+}
+
+// Encoder writes values to a journal stream.
+type Encoder interface{ Encode(v any) error }
+
+// Decoder reads values from a journal stream.
+type Decoder interface{ Decode(v any) error }
+
+type gobCodec struct{}
+
+func (gobCodec) NewEncoder(w io.Writer) Encoder { return gob.NewEncoder(w) }
+func (gobCodec) NewDecoder(r io.Reader) Decoder { return gob.NewDecoder(r) }
+
+type jsonCodec struct{}
+
+func (jsonCodec) NewEncoder(w io.Writer) Encoder { return json.NewEncoder(w) }
+func (jsonCodec) NewDecoder(r io.Reader) Decoder { return json.NewDecoder(r) }
+
+// Built-in codecs. Gob is compact and the default; JSON can be read and
+// edited by hand.
+var (
+	Gob  Codec = gobCodec{}
+	JSON Codec = jsonCodec{}
+)
+
+// journalVersion is the version of the journal format.
+const journalVersion = 1
+
+// journalStart opens a journal.
+type journalStart struct {
+	Version int
+}
+
+// journalBatch precedes the Count events of one type published in one frame.
+// Frame counts from the start of the recording.
+type journalBatch struct {
+	Frame uint64
+	Type  string
+	Count int
+}
+
+// unjournaled is implemented by event types that are never recorded, such as
+// requests, whose replies cannot be replayed.
+type unjournaled interface{ unjournaled() }
+
+func (request[T, R]) unjournaled() {}
+
+// journaled reports whether events of type t are recorded and replayed.
+func journaled(t reflect.Type) bool {
+	return !t.Implements(reflect.TypeFor[unjournaled]())
+}
+
+// journalName is the name a type is recorded under.
+func journalName(t reflect.Type) string {
+	return t.String()
+}
+
+// batch is the events of one type published by the current Advance.
+type batch struct {
+	name   string
+	n      int
+	encode func(Encoder) error
+}
+
+// Journal records the events published on a Bus. Create it with Bus.Record.
+type Journal struct {
+	bus     *Bus
+	w       io.Writer
+	buf     *bufio.Writer
+	enc     Encoder
+	start   uint64
+	mu      sync.Mutex
+	batches []batch
+	closed  bool
+	err     error
+}
+
+// Record starts recording every event published by Advance to w, encoded
+// with c (Gob if nil), until the returned Journal is closed. Each frame's
+// events are written by type name, then in emission order, and flushed at the
+// end of the frame, so a journal cut short by a crash keeps every complete
+// frame. Request events are not recorded. Recording replaces a journal
+// already attached to the bus.
+func (b *Bus) Record(w io.Writer, c Codec) *Journal {
+	if c == nil {
+		c = Gob
+	}
+	buf := bufio.NewWriter(w)
+	j := &Journal{bus: b, w: w, buf: buf, enc: c.NewEncoder(buf), start: b.Frame()}
+	j.err = j.enc.Encode(journalStart{Version: journalVersion})
+	b.journal.Store(j)
+	return j
+}
+
+// add queues the events of one type for the frame being advanced.
+func (j *Journal) add(name string, n int, encode func(Encoder) error) {
+	j.mu.Lock()
+	j.batches = append(j.batches, batch{name: name, n: n, encode: encode})
+	j.mu.Unlock()
+}
+
+// flush writes the batches queued for frame, ordered by type name.
+func (j *Journal) flush(frame uint64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	slices.SortFunc(j.batches, func(a, b batch) int { return strings.Compare(a.name, b.name) })
+	for _, bt := range j.batches {
+		if j.closed || j.err != nil {
+			break
+		}
+		j.err = j.enc.Encode(journalBatch{Frame: frame - j.start, Type: bt.name, Count: bt.n})
+		if j.err == nil {
+			j.err = bt.encode(j.enc)
+		}
+	}
+	clear(j.batches)
+	j.batches = j.batches[:0]
+	if !j.closed && j.err == nil {
+		j.err = j.buf.Flush()
+	}
+}
+
+// Err returns the first error encountered while recording. Recording stops
+// at the first error.
+func (j *Journal) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Close stops recording, flushes the journal and closes the underlying
+// writer if it is an io.Closer. It returns the first error encountered.
+func (j *Journal) Close() error {
+	j.bus.journal.CompareAndSwap(j, nil)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return j.err
+	}
+	j.closed = true
+	if j.err == nil {
+		j.err = j.buf.Flush()
+	}
+	if c, ok := j.w.(io.Closer); ok {
+		if err := c.Close(); j.err == nil {
+			j.err = err
+		}
+	}
+	return j.err
+}
+
+// replayer is implemented by every store to inject decoded journal events.
+type replayer interface {
+	elemType() reflect.Type
+	replay(dec Decoder, n int) error
+}
+
+// replay decodes n events and appends them to the write buffer, bypassing
+// the suppression of live writes.
+func (s *store[T]) replay(dec Decoder, n int) error {
+	for range n {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		s.appendWith(v, nil, false)
+	}
+	return nil
+}
+
+// Replay injects the events of a journal into a Bus. Create it with
+// Bus.Replay.
+type Replay struct {
+	bus     *Bus
+	r       io.Reader
+	dec     Decoder
+	start   uint64
+	next    journalBatch
+	pending bool // next holds a batch whose events are not read yet
+	mu      sync.Mutex
+	done    bool
+	err     error
+}
+
+// Replay injects the events recorded in the journal r, encoded with c (Gob
+// if nil), frame by frame: the events recorded in the journal's first frame
+// are emitted right away, and those of each following frame when Advance
+// starts it, so they are published at the same Advance as when recorded.
+// While replaying, live writes of journaled types are discarded, so the
+// journal is the only source of events; with a deterministic schedule the
+// App then reproduces the recorded run. Live writes resume once the journal
+// is exhausted or the replay is stopped.
+//
+// Recorded types are matched by name against the types stored on the bus,
+// so each must have a writer, a reader or be registered with RegisterType
+// before its first event is replayed.
+func (b *Bus) Replay(r io.Reader, c Codec) (*Replay, error) {
+	if c == nil {
+		c = Gob
+	}
+	dec := c.NewDecoder(bufio.NewReader(r))
+	var start journalStart
+	if err := dec.Decode(&start); err != nil {
+		return nil, fmt.Errorf("event journal: %w", err)
+	}
+	if start.Version != journalVersion {
+		return nil, fmt.Errorf("event journal: unsupported version %d", start.Version)
+	}
+	p := &Replay{bus: b, r: r, dec: dec, start: b.Frame()}
+	b.replaying.Store(true)
+	b.replay.Store(p)
+	p.inject(b.Frame())
+	return p, nil
+}
+
+// RegisterType creates the store for T, so journals can replay events of T
+// before any writer or reader for it exists.
+func RegisterType[T any](b *Bus) {
+	ensureStore[T](b)
+}
+
+// inject emits the events recorded for the given bus frame.
+func (p *Replay) inject(frame uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+	k := frame - p.start
+	for {
+		if !p.pending {
+			if err := p.dec.Decode(&p.next); err != nil {
+				if !errors.Is(err, io.EOF) {
+					p.err = fmt.Errorf("event journal: %w", err)
+				}
+				p.finish()
+				return
+			}
+			p.pending = true
+		}
+		if p.next.Frame > k {
+			return
+		}
+		p.pending = false
+		rp := p.bus.storeNamed(p.next.Type)
+		if rp == nil {
+			p.err = fmt.Errorf("event journal: unknown event type %s", p.next.Type)
+			p.finish()
+			return
+		}
+		if err := rp.replay(p.dec, p.next.Count); err != nil {
+			p.err = fmt.Errorf("event journal: %s: %w", p.next.Type, err)
+			p.finish()
+			return
+		}
+	}
+}
+
+// finish ends the replay and resumes live writes. Callers must hold p.mu.
+func (p *Replay) finish() {
+	p.done = true
+	if p.bus.replay.CompareAndSwap(p, nil) {
+		p.bus.replaying.Store(false)
+	}
+	if c, ok := p.r.(io.Closer); ok {
+		if err := c.Close(); p.err == nil && err != nil {
+			p.err = err
+		}
+	}
+}
+
+// Done reports whether the replay has ended, because the journal was
+// exhausted, an error occurred or Stop was called.
+func (p *Replay) Done() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done
+}
+
+// Err returns the error that ended the replay, if any.
+func (p *Replay) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// Stop ends the replay before the journal is exhausted and resumes live
+// writes. It returns the error that ended the replay, if any.
+func (p *Replay) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.done {
+		p.finish()
+	}
+	return p.err
+}
+
+// storeNamed returns the journaled store recorded under name, or nil.
+func (b *Bus) storeNamed(name string) replayer {
+	var found replayer
+	b.stores.Range(func(_, v any) bool {
+		if rp, ok := v.(replayer); ok && journaled(rp.elemType()) && journalName(rp.elemType()) == name {
+			found = rp
+			return false
+		}
+		return true
+	})
+	return found
+}
@@ -0,0 +1,381 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// linkVersion is the version of the link protocol.
+const linkVersion = 1
+
+// linkHello opens each direction of a link.
+type linkHello struct {
+	Version int
+}
+
+// linkHeader precedes every message of a link. Events carry their type name
+// and are followed by their value; results have an empty Type.
+type linkHeader struct {
+	Type      string
+	ID        uint64 // result id; 0 if the sender does not wait for a result
+	Cancelled bool   // for results: a remote reader cancelled the event
+}
+
+// SendOptions configures how Send forwards an event type.
+type SendOptions struct {
+	// Results makes events emitted with EmitResult wait for the peer: they
+	// complete once the peer's readers have processed the forwarded copy,
+	// and are cancelled if one of them cancels it. The link then counts as a
+	// declared reader of the type.
+	Results bool
+}
+
+// sink forwards the published events of a type over a link.
+type sink[T any] struct {
+	results bool
+	send    func(vals []T, results []*result)
+}
+
+// Link forwards selected event types between a Bus and a bus in another
+// process over a stream connection such as TCP or a Unix socket. Messages are
+// encoded with a Codec. Events sent by Send are forwarded when the local
+// Advance publishes them; received events enter the write buffer of the
+// local bus, as if emitted by a writer, and are published by its next
+// Advance. Register the forwarded types with Send and Receive, then call
+// Start.
+type Link struct {
+	bus     *Bus
+	conn    io.ReadWriteCloser
+	codec   Codec
+	mu      sync.Mutex
+	recv    map[string]func(dec Decoder, id uint64) error
+	pending map[uint64]*result // forwarded results awaiting the peer's reply
+	nextID  uint64
+	queue   []func(Encoder) error
+	wake    chan struct{}
+	undo    []func()
+	started bool
+	closed  bool
+	err     error
+	done    chan struct{}
+}
+
+// NewLink returns a link between b and the peer at the other end of conn,
+// encoded with c (Gob if nil). Both ends must use the same codec.
+func NewLink(b *Bus, conn io.ReadWriteCloser, c Codec) *Link {
+	if c == nil {
+		c = Gob
+	}
+	return &Link{
+		bus:     b,
+		conn:    conn,
+		codec:   c,
+		recv:    make(map[string]func(Decoder, uint64) error),
+		pending: make(map[uint64]*result),
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// Dial connects to a peer listening on the given network address, e.g.
+// "tcp" or "unix", and returns a link for b.
+func Dial(ctx context.Context, network, addr string, b *Bus, c Codec) (*Link, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewLink(b, conn, c), nil
+}
+
+// Accept waits for the next peer on ln and returns a link for b.
+func Accept(ln net.Listener, b *Bus, c Codec) (*Link, error) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return NewLink(b, conn, c), nil
+}
+
+// Loopback returns two connected in-memory links, one for each bus. It is
+// meant for tests.
+func Loopback(a, b *Bus, c Codec) (*Link, *Link) {
+	ca, cb := net.Pipe()
+	return NewLink(a, ca, c), NewLink(b, cb, c)
+}
+
+// Send forwards the events of type T published on the link's bus to the
+// peer, which must Receive them. A type both sent and received over the
+// same link loops back to its origin.
+func Send[T any](l *Link, opts SendOptions) {
+	st := ensureStore[T](l.bus)
+	name := journalName(reflect.TypeFor[T]())
+	sk := &sink[T]{results: opts.Results}
+	sk.send = func(vals []T, results []*result) {
+		ids := make([]uint64, len(vals))
+		l.mu.Lock()
+		if l.closed {
+			l.mu.Unlock()
+			if sk.results {
+				for _, r := range results {
+					if r != nil {
+						r.remoteDone(false)
+					}
+				}
+			}
+			return
+		}
+		if sk.results {
+			for i, r := range results {
+				if r != nil {
+					l.nextID++
+					ids[i] = l.nextID
+					l.pending[ids[i]] = r
+				}
+			}
+		}
+		l.queue = append(l.queue, func(enc Encoder) error {
+			for i, v := range vals {
+				if err := enc.Encode(linkHeader{Type: name, ID: ids[i]}); err != nil {
+					return err
+				}
+				if err := enc.Encode(v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		l.mu.Unlock()
+		l.signal()
+	}
+
+	st.mu.Lock()
+	st.sinks = append(st.sinks, sk)
+	st.mu.Unlock()
+	if opts.Results {
+		st.declared.Add(1)
+	}
+	l.onClose(func() {
+		st.mu.Lock()
+		if i := slices.Index(st.sinks, sk); i >= 0 {
+			st.sinks = slices.Delete(st.sinks, i, i+1)
+		}
+		st.mu.Unlock()
+		if opts.Results {
+			st.declared.Add(-1)
+		}
+	})
+}
+
+// Receive accepts events of type T from the peer. Register it before Start:
+// an event of a type not received ends the link with an error. Events the
+// peer sends with results are emitted with EmitResult, and their completion
+// and cancellation are reported back.
+func Receive[T any](l *Link) {
+	st := ensureStore[T](l.bus)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recv[journalName(reflect.TypeFor[T]())] = func(dec Decoder, id uint64) error {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		if id == 0 {
+			st.appendValue(v)
+			return nil
+		}
+		st.appendResult(v).onComplete(func(cancelled bool) {
+			l.reply(id, cancelled)
+		})
+		return nil
+	}
+}
+
+// Start starts exchanging events with the peer. It returns immediately.
+func (l *Link) Start() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.started || l.closed {
+		return
+	}
+	l.started = true
+	buf := bufio.NewWriter(l.conn)
+	enc := l.codec.NewEncoder(buf)
+	l.queue = slices.Insert(l.queue, 0, func(enc Encoder) error {
+		return enc.Encode(linkHello{Version: linkVersion})
+	})
+	go l.write(enc, buf)
+	go l.read(l.codec.NewDecoder(bufio.NewReader(l.conn)))
+	l.signalLocked()
+}
+
+// Done returns a channel closed once the link has ended.
+func (l *Link) Done() <-chan struct{} {
+	return l.done
+}
+
+// Err returns the error that ended the link, or nil if it is open or was
+// closed without error.
+func (l *Link) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Close ends the link and closes its connection. Events still waiting for a
+// result from the peer complete as not cancelled.
+func (l *Link) Close() error {
+	l.fail(nil)
+	return l.Err()
+}
+
+// reply queues the result of a received event for the peer.
+func (l *Link) reply(id uint64, cancelled bool) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return
+	}
+	l.queue = append(l.queue, func(enc Encoder) error {
+		return enc.Encode(linkHeader{ID: id, Cancelled: cancelled})
+	})
+	l.mu.Unlock()
+	l.signal()
+}
+
+// signal wakes the writer.
+func (l *Link) signal() {
+	l.mu.Lock()
+	l.signalLocked()
+	l.mu.Unlock()
+}
+
+func (l *Link) signalLocked() {
+	if !l.started {
+		return
+	}
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+// onClose registers fn to run when the link ends, or runs it right away if
+// it has.
+func (l *Link) onClose(fn func()) {
+	l.mu.Lock()
+	if !l.closed {
+		l.undo = append(l.undo, fn)
+		fn = nil
+	}
+	l.mu.Unlock()
+	if fn != nil {
+		fn()
+	}
+}
+
+// write encodes queued messages until the link ends.
+func (l *Link) write(enc Encoder, buf *bufio.Writer) {
+	for {
+		select {
+		case <-l.wake:
+		case <-l.done:
+			return
+		}
+		l.mu.Lock()
+		queue := l.queue
+		l.queue = nil
+		l.mu.Unlock()
+		for _, fn := range queue {
+			if err := fn(enc); err != nil {
+				l.fail(err)
+				return
+			}
+		}
+		if err := buf.Flush(); err != nil {
+			l.fail(err)
+			return
+		}
+	}
+}
+
+// read decodes messages from the peer until the link ends.
+func (l *Link) read(dec Decoder) {
+	var hello linkHello
+	if err := dec.Decode(&hello); err != nil {
+		l.fail(err)
+		return
+	}
+	if hello.Version != linkVersion {
+		l.fail(fmt.Errorf("unsupported version %d", hello.Version))
+		return
+	}
+	for {
+		var h linkHeader
+		if err := dec.Decode(&h); err != nil {
+			l.fail(err)
+			return
+		}
+		if h.Type == "" {
+			l.mu.Lock()
+			r := l.pending[h.ID]
+			delete(l.pending, h.ID)
+			l.mu.Unlock()
+			if r != nil {
+				r.remoteDone(h.Cancelled)
+			}
+			continue
+		}
+		l.mu.Lock()
+		fn := l.recv[h.Type]
+		l.mu.Unlock()
+		if fn == nil {
+			l.fail(fmt.Errorf("unexpected event type %s", h.Type))
+			return
+		}
+		if err := fn(dec, h.ID); err != nil {
+			l.fail(fmt.Errorf("%s: %w", h.Type, err))
+			return
+		}
+	}
+}
+
+// fail ends the link, recording err unless the link was already closed or
+// the peer hung up. It releases the results still awaiting the peer.
+func (l *Link) fail(err error) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return
+	}
+	l.closed = true
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrClosedPipe) && !errors.Is(err, net.ErrClosed) {
+		l.err = fmt.Errorf("event link: %w", err)
+	}
+	pending := l.pending
+	l.pending = nil
+	undo := l.undo
+	l.undo = nil
+	l.queue = nil
+	close(l.done)
+	l.mu.Unlock()
+
+	if cerr := l.conn.Close(); cerr != nil && err == nil && !errors.Is(cerr, net.ErrClosed) {
+		l.mu.Lock()
+		l.err = fmt.Errorf("event link: %w", cerr)
+		l.mu.Unlock()
+	}
+	for _, fn := range undo {
+		fn()
+	}
+	for _, r := range pending {
+		r.remoteDone(false)
+	}
+}
@@ -1,20 +1,89 @@
 package event
 
-// Reader iterates over the current read buffer snapshot (the previous frame's writes).
-// It supports per-event cancellation via Cancel() during iteration and exposes the
-// current event's cancellation state via IsCancelled(). For batch extraction, use
-// Drain or DrainTo.
+import "sync/atomic"
+
+// Priority orders the readers of an event type. The scheduler runs systems
+// reading the same event type from Lowest to Monitor, so cancellations made
+// at lower priorities are visible to higher ones. The zero value is Normal.
+type Priority int8
+
+const (
+	Lowest Priority = iota - 2
+	Low
+	Normal
+	High
+	Highest
+	// Monitor readers run last and observe the final outcome; Cancel is a
+	// no-op for them.
+	Monitor
+)
+
+// String returns the priority name.
+func (p Priority) String() string {
+	switch p {
+	case Lowest:
+		return "Lowest"
+	case Low:
+		return "Low"
+	case Normal:
+		return "Normal"
+	case High:
+		return "High"
+	case Highest:
+		return "Highest"
+	case Monitor:
+		return "Monitor"
+	default:
+		return "Unknown"
+	}
+}
+
+// ReaderOptions configures a reader created with ReaderWith.
+type ReaderOptions struct {
+	// Priority is the reader's level. It only affects Cancel here; ordering
+	// comes from the priorities declared in the system's access metadata.
+	Priority Priority
+	// IgnoreCancelled skips events already cancelled by another reader. They
+	// still count as processed by this reader.
+	IgnoreCancelled bool
+}
+
+// Reader consumes events through its own cursor into the store's retained
+// window, so every event published by Advance is seen exactly once, even by
+// readers that skip frames (up to the retention limit) or run several times
+// per frame. Copies of a Reader share the cursor; create one reader per
+// consumer with ReaderFor. A Reader must not be used concurrently.
+//
+// It supports per-event cancellation via Cancel() during iteration and exposes
+// the current event's cancellation state via IsCancelled(). For batch
+// extraction, use Drain or DrainTo.
 type Reader[T any] struct {
 	store *store[T]
-	cur   *entry[T] // current entry for Cancel()/IsCancelled()
+	state *readerState
+	cur   current[T] // current event for Cancel()/IsCancelled()
+	opts  ReaderOptions
+	fan   *fanIn[T] // set for interface readers
 }
 
 // Cancel marks the current event as cancelled. Call inside the ForEach() callback.
 // Cancellation is visible to writers via EventResult.Cancelled/Wait/WaitCancelled
 // and to other readers via IsCancelled while iterating the same event.
+// Monitor readers cannot cancel.
 func (r *Reader[T]) Cancel() {
-	if r.cur != nil {
-		r.cur.cancelled.Store(true)
+	if r.fan != nil {
+		if r.fan.cur != nil {
+			r.fan.cur.Cancel()
+		}
+		return
+	}
+	if r.cur.flag == nil || r.opts.Priority == Monitor {
+		return
+	}
+	if atomic.OrUint32(r.cur.flag, flagCancelled)&flagCancelled == 0 {
+		r.store.cancels.Add(1)
+	}
+	if r.cur.res != nil {
+		r.cur.res.markCancelled()
 	}
 }
 
@@ -24,15 +93,16 @@ func (r *Reader[T]) Cancel() {
 // It is safe to call from within the ForEach callback.
 // If no iteration is active, it returns false.
 func (r *Reader[T]) IsCancelled() bool {
-	if r.cur == nil {
-		return false
+	if r.fan != nil {
+		return r.fan.cur != nil && r.fan.cur.IsCancelled()
 	}
-	return r.cur.cancelled.Load()
+	return r.cur.flag != nil && atomic.LoadUint32(r.cur.flag)&flagCancelled != 0
 }
 
-// ForEach iterates the current read buffer snapshot with a callback.
+// ForEach iterates the events this reader has not seen yet with a callback.
 // This is the recommended, zero-allocation iteration method. The callback
-// should return `false` to stop iteration early.
+// should return `false` to stop iteration early; the remaining events stay
+// unread and are delivered by the next call.
 //
 // Example:
 //
@@ -43,60 +113,114 @@ func (r *Reader[T]) IsCancelled() bool {
 //	    return true // return false to stop
 //	})
 //
-// It safely handles completion tracking, even with early exits.
+// Each event counts as processed by this reader once its callback returns, and
+// completes when every registered reader has processed it.
 func (r *Reader[T]) ForEach(yield func(T) bool) {
-	entries := r.store.snapshotEntries()
-	if len(entries) == 0 {
+	r.each(func(v *T) bool { return yield(*v) })
+}
+
+// ForEachMut is like ForEach but yields a pointer to the stored event, so a
+// reader can adjust the event for the readers that run after it. Systems using
+// it must declare AccessEventMutate, which serializes them against every other
+// reader and writer of the type; the pointer must not be retained after the
+// callback returns. For interface readers the pointer refers to a copy of the
+// interface value, so only changes through pointer-typed events are seen by
+// later readers.
+func (r *Reader[T]) ForEachMut(yield func(*T) bool) {
+	r.each(yield)
+}
+
+func (r *Reader[T]) each(yield func(*T) bool) {
+	if r.fan != nil {
+		r.fan.each(yield)
 		return
 	}
-
-	// First, register this reader for all non-completed entries. This is done
-	// in a separate pass to ensure that even if the loop breaks early, all
-	// events that *could* have been seen are accounted for.
-	for _, ent := range entries {
-		if !ent.IsDone() {
-			ent.pending.Add(1)
+	if r.store == nil {
+		return
+	}
+	w := r.store.unread(r.state)
+	for i := range w.vals {
+		if !r.visit(&w, i, yield) {
+			break
 		}
 	}
+	r.cur = current[T]{}
+}
+
+// visit yields the i-th event of w, which must be the next unread one, and
+// marks it as read. It returns yield's result.
+func (r *Reader[T]) visit(w *window[T], i int, yield func(*T) bool) bool {
+	pos := w.first + uint64(i)
+	r.cur = current[T]{val: &w.vals[i], flag: &w.flags[i], res: w.results[i], seq: w.seq(i)}
+	r.state.next = pos + 1
+	ok := true
+	if !r.opts.IgnoreCancelled || atomic.LoadUint32(&w.flags[i])&flagCancelled == 0 {
+		ok = yield(&w.vals[i])
+	}
+	// Events published before this reader registered don't count it.
+	if w.results[i] != nil && pos >= r.state.since {
+		w.results[i].dec()
+	}
+	return ok
+}
 
-	// Now, iterate and process.
-	for i, ent := range entries {
-		r.cur = ent
-		// Only yield if the entry is not done; otherwise, just clean it up.
-		if !ent.IsDone() {
-			if !yield(ent.val) {
-				ent.dec()
-				// If we stopped early, we still need to decrement the pending count
-				// for all the remaining entries we registered but didn't process.
-				// The current entry `ent` has already been decremented.
-				for j := i + 1; j < len(entries); j++ {
-					entries[j].dec()
-				}
-				break
-			}
+// Seq returns the global sequence number of the current event, which orders
+// it against the events of every sequenced type (see Sequence). It is 0 for
+// types that are not sequenced and outside ForEach.
+func (r *Reader[T]) Seq() uint64 {
+	if r.fan != nil {
+		if r.fan.cur != nil {
+			return r.fan.cur.Seq()
 		}
-		ent.dec()
+		return 0
 	}
-	r.cur = nil
+	return r.cur.seq
 }
 
-// Drain returns the values of the current read buffer and clears it.
+// Close unregisters the reader: events published from now on no longer wait
+// for it, and those it has not read yet are released as if it had processed
+// them. The reader and its copies read nothing afterwards. Close readers that
+// are no longer used, as an abandoned reader keeps every EmitResult pending
+// until the event is evicted. An interface reader closes its reader of every
+// implementing type and stops picking up new ones.
+func (r *Reader[T]) Close() {
+	if r.fan != nil {
+		r.fan.close()
+		return
+	}
+	if r.store == nil {
+		return
+	}
+	r.store.closeReader(r.state)
+}
+
+// Drain returns the values of all unread events and marks them as read.
 // Prefer ForEach() for proper completion semantics; Drain is provided for special cases
-// and does not register readers, so writers may rely on CompleteNoReader to resolve.
+// and does not count as processing, so drained events complete when they are evicted.
 func (r Reader[T]) Drain() []T {
-	return r.store.drain()
+	if r.fan != nil {
+		return r.fan.drain(-1)
+	}
+	if r.store == nil {
+		return nil
+	}
+	return r.store.drain(r.state, -1)
 }
 
-// DrainTo fills the provided buffer with events from the current read buffer and clears it.
+// DrainTo fills the provided buffer with unread events and marks them as read.
 // It returns the number of events written to dst. If dst is smaller than the number
-// of available events, only the first len(dst) are copied.
+// of available events, only the first len(dst) are copied; the rest stay unread.
 // Prefer ForEach() for proper completion semantics; DrainTo is for special cases.
 func (r Reader[T]) DrainTo(dst []T) int {
 	if len(dst) == 0 {
 		return 0
 	}
-	vals := r.store.drain()
-	n := min(len(vals), len(dst))
-	copy(dst, vals[:n])
-	return n
+	if r.fan != nil {
+		return copy(dst, r.fan.drain(len(dst)))
+	}
+	if r.store == nil {
+		return 0
+	}
+	vals := r.store.drain(r.state, len(dst))
+	return copy(dst, vals)
 }
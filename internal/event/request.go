@@ -0,0 +1,229 @@
+package event
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// request is the payload stored for request events. Requests live in their
+// own store, separate from plain events of type T.
+type request[T, R any] struct {
+	val T
+	res *replies[R]
+}
+
+// replies collects the responses attached to a single request.
+type replies[R any] struct {
+	mu    sync.Mutex
+	vals  []R
+	first chan struct{} // closed on the first reply
+}
+
+func (rs *replies[R]) add(v R) {
+	rs.mu.Lock()
+	rs.vals = append(rs.vals, v)
+	if len(rs.vals) == 1 {
+		close(rs.first)
+	}
+	rs.mu.Unlock()
+}
+
+func (rs *replies[R]) snapshot() []R {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return append([]R(nil), rs.vals...)
+}
+
+type mergeKind int
+
+const (
+	mergeFirst mergeKind = iota
+	mergeLast
+	mergeReduce
+)
+
+// Merge selects how the replies to a request are combined into the single
+// value returned by Request.Await. Use AwaitAll to collect every reply.
+type Merge[R any] struct {
+	kind   mergeKind
+	init   R
+	reduce func(acc, next R) R
+}
+
+// First keeps the first reply. Await returns as soon as it arrives.
+func First[R any]() Merge[R] {
+	return Merge[R]{kind: mergeFirst}
+}
+
+// Last keeps the last reply once the request has completed.
+func Last[R any]() Merge[R] {
+	return Merge[R]{kind: mergeLast}
+}
+
+// Reduce folds all replies, in reply order, into init using fn once the
+// request has completed.
+func Reduce[R any](init R, fn func(acc, next R) R) Merge[R] {
+	return Merge[R]{kind: mergeReduce, init: init, reduce: fn}
+}
+
+func (m Merge[R]) apply(vals []R) R {
+	switch m.kind {
+	case mergeLast:
+		return vals[len(vals)-1]
+	case mergeReduce:
+		acc := m.init
+		for _, v := range vals {
+			acc = m.reduce(acc, v)
+		}
+		return acc
+	default:
+		return vals[0]
+	}
+}
+
+// mergeFor returns the bus-wide merge policy registered for requests T -> R.
+func mergeFor[T, R any](b *Bus) Merge[R] {
+	if v, ok := b.merges.Load(RequestType[T, R]()); ok {
+		return v.(Merge[R])
+	}
+	return First[R]()
+}
+
+// SetRequestMerge sets the merge policy used by request writers for T -> R on
+// this bus. The default is First.
+func SetRequestMerge[T, R any](b *Bus, m Merge[R]) {
+	b.merges.Store(RequestType[T, R](), m)
+}
+
+// RequestType returns the type under which requests T -> R are stored, for
+// access metadata and reader registration.
+func RequestType[T, R any]() reflect.Type {
+	return reflect.TypeOf((*request[T, R])(nil)).Elem()
+}
+
+// RequestWriter emits request events of type T that readers answer with R.
+type RequestWriter[T, R any] struct {
+	w     Writer[request[T, R]]
+	bus   *Bus
+	merge *Merge[R]
+}
+
+// RequestWriterFor returns a request writer bound to this bus.
+func RequestWriterFor[T, R any](b *Bus) RequestWriter[T, R] {
+	return RequestWriter[T, R]{w: WriterFor[request[T, R]](b), bus: b}
+}
+
+// WithMerge returns a copy of the writer using m instead of the bus-wide
+// merge policy.
+func (w RequestWriter[T, R]) WithMerge(m Merge[R]) RequestWriter[T, R] {
+	w.merge = &m
+	return w
+}
+
+// EmitRequest appends a request event and returns a handle to await replies.
+func (w RequestWriter[T, R]) EmitRequest(v T) Request[T, R] {
+	if w.w.store == nil {
+		return Request[T, R]{}
+	}
+	m := First[R]()
+	if w.merge != nil {
+		m = *w.merge
+	} else if w.bus != nil {
+		m = mergeFor[T, R](w.bus)
+	}
+	res := &replies[R]{first: make(chan struct{})}
+	er := w.w.EmitResult(request[T, R]{val: v, res: res})
+	return Request[T, R]{EventResult: er, res: res, merge: m}
+}
+
+// Request is the handle of an emitted request. It embeds the EventResult of
+// the underlying event, so it also supports Wait, WaitCancelled, Done,
+// OnComplete and the WaitAll/WaitAny combinators.
+type Request[T, R any] struct {
+	EventResult[request[T, R]]
+	res   *replies[R]
+	merge Merge[R]
+}
+
+// Await blocks until the request has completed (or, with the First policy,
+// until the first reply arrives) or ctx is done, and returns the merged reply.
+// The boolean is false if no reader replied.
+func (q Request[T, R]) Await(ctx context.Context) (R, bool) {
+	var zero R
+	if q.res == nil {
+		return zero, false
+	}
+	if q.merge.kind == mergeFirst {
+		select {
+		case <-q.res.first:
+		case <-q.Done():
+		case <-ctx.Done():
+		}
+	} else {
+		select {
+		case <-q.Done():
+		case <-ctx.Done():
+		}
+	}
+	vals := q.res.snapshot()
+	if len(vals) == 0 {
+		return zero, false
+	}
+	return q.merge.apply(vals), true
+}
+
+// AwaitAll blocks until the request has completed or ctx is done, and returns
+// every reply in the order they were attached.
+func (q Request[T, R]) AwaitAll(ctx context.Context) []R {
+	if q.res == nil {
+		return nil
+	}
+	select {
+	case <-q.Done():
+	case <-ctx.Done():
+	}
+	return q.res.snapshot()
+}
+
+// RequestReader consumes request events of type T and attaches replies of
+// type R. Like Reader, it keeps its own cursor.
+type RequestReader[T, R any] struct {
+	r Reader[request[T, R]]
+}
+
+// RequestReaderFor returns a new request reader bound to this bus.
+func RequestReaderFor[T, R any](b *Bus) RequestReader[T, R] {
+	return RequestReaderWith[T, R](b, ReaderOptions{})
+}
+
+// RequestReaderWith is like RequestReaderFor but applies the given options.
+func RequestReaderWith[T, R any](b *Bus, opts ReaderOptions) RequestReader[T, R] {
+	return RequestReader[T, R]{r: ReaderWith[request[T, R]](b, opts)}
+}
+
+// ForEach iterates the unread requests with a callback. Call Reply or Cancel
+// inside the callback to answer or cancel the current request.
+func (r *RequestReader[T, R]) ForEach(yield func(T) bool) {
+	r.r.ForEach(func(q request[T, R]) bool {
+		return yield(q.val)
+	})
+}
+
+// Reply attaches a response to the current request. Call inside ForEach; a
+// reader may reply more than once.
+func (r *RequestReader[T, R]) Reply(v R) {
+	if r.r.cur.val != nil {
+		r.r.cur.val.res.add(v)
+	}
+}
+
+// Cancel marks the current request as cancelled. Call inside ForEach.
+func (r *RequestReader[T, R]) Cancel() {
+	r.r.Cancel()
+}
+
+// IsCancelled reports whether the current request has been cancelled.
+func (r *RequestReader[T, R]) IsCancelled() bool {
+	return r.r.IsCancelled()
+}
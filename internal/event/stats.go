@@ -0,0 +1,108 @@
+package event
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Stats describes one event type registered on a Bus.
+type Stats struct {
+	// Type is the event type. Request types appear under their internal
+	// request type.
+	Type reflect.Type
+	// Name is the name reported to Diagnostics.
+	Name string
+	// Pending is the number of events written this frame, to be published by
+	// the next Advance.
+	Pending int
+	// Retained is the number of published events still readable.
+	Retained int
+	// Waiters is the number of events emitted with EmitResult that have not
+	// completed yet.
+	Waiters int
+	// Readers is the number of registered reader cursors.
+	Readers int
+	// Declared is the number of readers declared through access metadata.
+	Declared int
+	// Subscribers is the number of active subscriptions.
+	Subscribers int
+	// Cancelled is the number of events cancelled by a reader so far.
+	Cancelled uint64
+	// Published is the number of events published so far.
+	Published uint64
+	// LastEmit is the frame in which the latest event was emitted (see
+	// Bus.Frame). It is meaningless while Pending and Published are 0.
+	LastEmit uint64
+}
+
+// Unread reports whether events of the type were emitted although nothing
+// reads them: no reader, declared reader or subscription.
+func (s Stats) Unread() bool {
+	return s.Pending+int(s.Published) > 0 && s.Readers+s.Declared+s.Subscribers == 0
+}
+
+// statser is implemented by the per-type store to support Bus.Stats.
+type statser interface{ stats(frame uint64) Stats }
+
+// Stats returns a snapshot of every event type on the bus, sorted by type
+// name. It locks each store briefly and is meant for debug overlays and
+// tooling, not for every frame of a hot path.
+func (b *Bus) Stats() []Stats {
+	frame := b.Frame()
+	var out []Stats
+	b.stores.Range(func(_, v any) bool {
+		if st, ok := v.(statser); ok {
+			out = append(out, st.stats(frame))
+		}
+		return true
+	})
+	slices.SortFunc(out, func(a, b Stats) int { return compareTypes(a.Type, b.Type) })
+	return out
+}
+
+func (s *store[T]) stats(frame uint64) Stats {
+	st := Stats{
+		Type:      reflect.TypeFor[T](),
+		Name:      s.name,
+		Readers:   int(s.readers.Load()),
+		Cancelled: s.cancels.Load(),
+	}
+	if s.declared != nil {
+		st.Declared = int(s.declared.Load())
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	st.Retained = len(s.vals)
+	st.Subscribers = len(s.subs)
+	st.Published = s.published
+	st.LastEmit = s.lastEmit
+	st.Pending, st.Waiters = waiting(s.queue())
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		n, w := waiting(sh.writes)
+		sh.mu.Unlock()
+		st.Pending += n
+		st.Waiters += w
+	}
+	if st.Pending > 0 {
+		st.LastEmit = frame
+	}
+	for _, r := range s.results {
+		if r != nil && !r.IsDone() {
+			st.Waiters++
+		}
+	}
+	return st
+}
+
+// waiting returns the number of writes and of incomplete results among them.
+func waiting[T any](writes []slot[T]) (n, waiters int) {
+	for _, sl := range writes {
+		if sl.res != nil && !sl.res.IsDone() {
+			waiters++
+		}
+	}
+	return len(writes), waiters
+}
@@ -1,8 +1,14 @@
 package event
 
 import (
+	"cmp"
+	"math/rand/v2"
+	"reflect"
+	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 var closedCh = func() chan struct{} {
@@ -11,72 +17,91 @@ var closedCh = func() chan struct{} {
 	return ch
 }()
 
-// entry represents a single emitted event and tracks its lifecycle.
+// result is the completion state of an event emitted with EmitResult. Events
+// emitted with Emit, EmitMany or EmitKeyed have none and are stored as plain
+// values.
 //
-//   - pending: number of readers currently registered to process this entry.
-//   - cancelled: set to true if any reader cancels while processing this entry.
-//   - done: completion signal, closed exactly once when pending reaches zero or when
-//     the system determines there will be no readers for the current frame.
+//   - pending: number of registered readers that have not processed the event yet,
+//     set when the event is published.
+//   - cancelled: set to true if any reader cancels while processing the event.
+//   - done: completion signal, closed exactly once when pending reaches zero, when
+//     the event is emitted with no reader registered, or when it is evicted.
+//   - cancelCh: closed when the first reader cancels, created lazily for WaitCancelled.
+//   - callbacks: OnComplete callbacks, run by whoever completes the event.
 //   - state: atomic bitset to guarantee single close without sync.Once.
-type entry[T any] struct {
-	val       T
+//   - remote: number of links still waiting for a peer's result; the event
+//     completes once they have replied and it is complete locally.
+type result struct {
 	pending   atomic.Int32
 	cancelled atomic.Bool
 	done      chan struct{}
+	cancelCh  chan struct{}
+	callbacks []func(cancelled bool)
 	doneMu    sync.Mutex
 	state     atomic.Uint32 // bit0: 1 = completed (done closed)
+	remote    atomic.Int32
+	local     atomic.Bool // completed locally while remote results were pending
 }
 
-func (s *store[T]) newEntry(v T, wantDone bool) *entry[T] {
-	if x := s.entryPool.Get(); x != nil {
-		e := x.(*entry[T])
-		// reset fields
-		var zero T
-		e.val = zero
-		e.val = v
-		e.pending.Store(0)
-		e.cancelled.Store(false)
-		e.state.Store(0)
-		// optionally create a fresh channel for completion signaling
-		if wantDone {
-			e.done = make(chan struct{})
-		} else {
-			e.done = nil
-		}
-		return e
+// dec records that one registered reader has processed the event and
+// completes it once all of them have.
+func (e *result) dec() {
+	if e.pending.Add(-1) == 0 {
+		e.complete()
 	}
-	if wantDone {
-		return &entry[T]{
-			val:  v,
-			done: make(chan struct{}),
-		}
+}
+
+// markCancelled sets the cancellation flag and wakes WaitCancelled callers.
+func (e *result) markCancelled() {
+	if !e.cancelled.CompareAndSwap(false, true) {
+		return
 	}
-	return &entry[T]{val: v}
+	e.doneMu.Lock()
+	if e.cancelCh != nil {
+		close(e.cancelCh)
+	} else {
+		e.cancelCh = closedCh
+	}
+	e.doneMu.Unlock()
 }
 
-// dec decrements the pending reader count.
-// Completion is deferred to advance() at frame end.
-func (e *entry[T]) dec() {
-	e.pending.Add(-1)
+// ensureCancelChan lazily creates the cancellation channel. If the event is
+// already cancelled, it returns a pre-closed channel.
+func (e *result) ensureCancelChan() chan struct{} {
+	e.doneMu.Lock()
+	if e.cancelCh == nil {
+		if e.cancelled.Load() {
+			e.cancelCh = closedCh
+		} else {
+			e.cancelCh = make(chan struct{})
+		}
+	}
+	ch := e.cancelCh
+	e.doneMu.Unlock()
+	return ch
 }
 
-// markCancelled sets the cancellation flag.
-// Readers should call this when they intend to cancel the current event.
-func (e *entry[T]) markCancelled() {
-	e.cancelled.Store(true)
+// onComplete registers fn to run when the event completes, or runs it right
+// away if it already has.
+func (e *result) onComplete(fn func(cancelled bool)) {
+	e.doneMu.Lock()
+	if !e.IsDone() {
+		e.callbacks = append(e.callbacks, fn)
+		e.doneMu.Unlock()
+		return
+	}
+	e.doneMu.Unlock()
+	fn(e.cancelled.Load())
 }
 
-// IsDone reports whether the entry has completed (its done channel has been signaled).
-func (e *entry[T]) IsDone() bool {
+// IsDone reports whether the event has completed (its done channel has been signaled).
+func (e *result) IsDone() bool {
 	return e.state.Load()&1 == 1
 }
 
 // ensureDoneChan lazily creates a done channel if it doesn't exist.
-// If the entry is already done, it sets a pre-closed channel to allow immediate wakeups.
-func (e *entry[T]) ensureDoneChan() chan struct{} {
-	if e.done != nil {
-		return e.done
-	}
+// If the event is already done, it sets a pre-closed channel to allow immediate wakeups.
+func (e *result) ensureDoneChan() chan struct{} {
 	e.doneMu.Lock()
 	if e.done == nil {
 		if e.IsDone() {
@@ -90,34 +115,464 @@ func (e *entry[T]) ensureDoneChan() chan struct{} {
 	return ch
 }
 
+// complete marks the event as done locally. It finishes the event unless a
+// link still waits for a peer's result, in which case the last reply does.
+func (e *result) complete() {
+	if e.remote.Load() > 0 {
+		e.local.Store(true)
+		if e.remote.Load() > 0 {
+			return
+		}
+	}
+	e.finish()
+}
+
+// remoteDone records a peer's result and finishes the event if it was the
+// last one and the event is complete locally.
+func (e *result) remoteDone(cancelled bool) {
+	if cancelled {
+		e.markCancelled()
+	}
+	if e.remote.Add(-1) == 0 && e.local.Load() {
+		e.finish()
+	}
+}
+
+// finish marks the event as done, wakes waiters and runs OnComplete callbacks
+// on the calling goroutine, exactly once.
+func (e *result) finish() {
+	e.doneMu.Lock()
+	if !e.state.CompareAndSwap(0, 1) {
+		e.doneMu.Unlock()
+		return
+	}
+	if e.done != nil {
+		close(e.done)
+	} else {
+		e.done = closedCh
+	}
+	cbs := e.callbacks
+	e.callbacks = nil
+	e.doneMu.Unlock()
+
+	if len(cbs) > 0 {
+		cancelled := e.cancelled.Load()
+		for _, fn := range cbs {
+			fn(cancelled)
+		}
+	}
+}
+
+// flagCancelled marks a retained event as cancelled by a reader.
+const flagCancelled uint32 = 1
+
+// slot is a pending write: the value, its emission stamp and, for
+// EmitResult, its completion state.
+type slot[T any] struct {
+	val   T
+	stamp uint64
+	res   *result
+}
+
+// current is the event a reader is visiting, for Cancel and IsCancelled.
+type current[T any] struct {
+	val  *T
+	flag *uint32
+	res  *result
+	seq  uint64
+}
+
+// shard is a write buffer of an unbounded store. Writers pick a shard at
+// random, so concurrent writers rarely share a lock.
+type shard[T any] struct {
+	mu     sync.Mutex
+	writes []slot[T]
+	_      [32]byte // pad to a cache line
+}
+
+// newShards returns GOMAXPROCS shards, rounded up to a power of two.
+func newShards[T any]() []shard[T] {
+	n := 1
+	for n < runtime.GOMAXPROCS(0) {
+		n <<= 1
+	}
+	return make([]shard[T], n)
+}
+
 // store is the per-type container for events.
-// It is double-buffered: writers append to writeEnt, while readers iterate readEnt.
+// Writers append to a randomly picked shard, or to writes under mu for
+// bounded and keyed writes. Each write is stamped from a per-store counter, or
+// from the Bus's global sequence once the type is sequenced, and Advance
+// merges the shards into writes in stamp order, so events keep the order they
+// were emitted in. It then moves the frame's values into a
+// retained window that readers consume through their own cursors; the window
+// keeps the last retention frames and completes events as they are evicted.
+// The window stores plain values contiguously, with a cancellation flag and
+// an optional result per value, so fire-and-forget events allocate nothing
+// beyond the amortized growth of these slices.
 type store[T any] struct {
-	mu        sync.RWMutex
-	readEnt   []*entry[T]
-	writeEnt  []*entry[T]
-	entryPool sync.Pool // pools *entry[T] to reduce allocations
-	name      string
-	diag      Diagnostics
+	mu         sync.RWMutex
+	shards     []shard[T]
+	stamp      atomic.Uint64                 // last emission stamp
+	stamps     atomic.Pointer[atomic.Uint64] // the Bus's sequence once sequenced, else nil
+	limited    atomic.Bool                   // a capacity is set; writes bypass the shards
+	writes     []slot[T]                     // locked writes, sorted by stamp; writes[:dropped] were dropped by DropOldest
+	dropped    int                           // leading writes dropped by DropOldest, compacted by advance
+	vals       []T                           // retained values; vals[i] has sequence number base+i
+	flags      []uint32                      // per retained value, accessed atomically
+	results    []*result                     // per retained value, nil unless emitted with EmitResult
+	seqs       []uint64                      // per retained value, global sequence number; nil unless sequenced
+	sequenced  bool                          // writes are stamped from the Bus's global sequence
+	base       uint64                        // sequence number of vals[0]
+	frames     []uint64                      // sequence number of the first value of each retained frame
+	retention  int                           // number of frames kept in the window
+	custom     bool                          // retention was set explicitly for this type
+	readers    atomic.Int32                  // registered reader cursors
+	declared   *atomic.Int32                 // readers declared via access metadata, shared with the Bus
+	subs       []*subscription[T]
+	sinks      []*sink[T] // links forwarding the type to a peer
+	capacity   Capacity[T]
+	room       chan struct{}  // closed by advance to wake writers blocked on capacity
+	keys       map[any]uint64 // stamp of the pending EmitKeyed write by key, cleared by advance
+	keyed      map[uint64]any // key of the pending EmitKeyed write by stamp, cleared by advance
+	keyedMerge func(prev, next T) T
+	timers     *timers       // the Bus's timing wheels, for EmitAfter and EmitAtFrame
+	replaying  *atomic.Bool  // set by the Bus while a journal is replayed
+	wakeup     *wakeup       // the Bus's wakeup, notified by every write
+	cancels    atomic.Uint64 // events cancelled by a reader, for Stats
+	published  uint64        // events published so far, for Stats
+	lastEmit   uint64        // frame of the latest published write, for Stats
+	journaled  bool          // events are recorded and replayed
+	name       string
+	diag       Diagnostics
+}
+
+// readerState is the shared cursor of a Reader and its copies.
+type readerState struct {
+	next   uint64 // sequence number of the next unread value
+	since  uint64 // first sequence number published while this reader was registered
+	closed bool   // unregistered by Close; guarded by the store's mu
+}
+
+// hasReaders reports whether a reader is registered or declared for the type,
+// or a subscription is active.
+func (s *store[T]) hasReaders() bool {
+	s.mu.RLock()
+	n := s.readers.Load() + int32(len(s.subs))
+	s.mu.RUnlock()
+	return n > 0 || (s.declared != nil && s.declared.Load() > 0)
+}
+
+// suppressed reports whether live writes are discarded because a journal is
+// being replayed.
+func (s *store[T]) suppressed() bool {
+	return s.journaled && s.replaying != nil && s.replaying.Load()
+}
+
+// appendValue appends a fire-and-forget event to the current write buffer.
+func (s *store[T]) appendValue(v T) {
+	if s.suppressed() {
+		return
+	}
+	s.appendWith(v, nil, true)
+}
+
+// appendResult appends an event with completion state and returns it. While
+// replaying, the event is discarded and its result completed right away.
+func (s *store[T]) appendResult(v T) *result {
+	if s.suppressed() {
+		res := &result{}
+		res.complete()
+		return res
+	}
+	return s.appendWith(v, &result{}, true)
+}
+
+// release appends an event scheduled with EmitAfter or EmitAtFrame. It runs
+// inside Advance, which cannot wait for itself, so a full buffer under Block
+// drops the event instead.
+func (s *store[T]) release(v T) {
+	if s.suppressed() {
+		return
+	}
+	s.appendWith(v, nil, false)
+}
+
+// appendWith appends v with the optional completion state res and returns the
+// completion state of the write now carrying the value.
+func (s *store[T]) appendWith(v T, res *result, wait bool) *result {
+	if s.diag != nil {
+		s.diag.EventEmit(s.name, 1)
+	}
+
+	if !s.limited.Load() {
+		sh := s.shard()
+		sh.mu.Lock()
+		sh.writes = append(sh.writes, slot[T]{val: v, stamp: s.nextStamp(), res: res})
+		sh.mu.Unlock()
+		s.wakeup.notify()
+		if res != nil && s.unreadable() {
+			res.complete()
+		}
+		return res
+	}
+
+	sl := slot[T]{val: v, res: res}
+	s.mu.Lock()
+	kept, dropped, blocked := s.push(&sl, wait)
+	s.mu.Unlock()
+
+	s.wakeup.notify()
+	s.overflowed(dropped, blocked)
+	if res != nil && kept == res && s.unreadable() {
+		res.complete()
+	}
+	return kept
+}
+
+// nextStamp returns the stamp of a new write: the next global sequence
+// number for sequenced types, the next per-store stamp otherwise.
+func (s *store[T]) nextStamp() uint64 {
+	if seq := s.stamps.Load(); seq != nil {
+		return seq.Add(1)
+	}
+	return s.stamp.Add(1)
+}
+
+// sequence makes the store stamp its writes from the global sequence seq, so
+// they can be ordered against other sequenced types. Values published
+// earlier get sequence number 0.
+func (s *store[T]) sequence(seq *atomic.Uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sequenced {
+		return
+	}
+	// Keep stamps increasing for writes pending across the switch.
+	for local := s.stamp.Load(); ; {
+		g := seq.Load()
+		if g >= local || seq.CompareAndSwap(g, local) {
+			break
+		}
+	}
+	s.stamps.Store(seq)
+	s.sequenced = true
+	s.seqs = make([]uint64, len(s.vals))
+}
+
+// shard returns a random write shard.
+func (s *store[T]) shard() *shard[T] {
+	return &s.shards[rand.Uint32()&uint32(len(s.shards)-1)]
+}
+
+// unreadable reports whether nobody can read new events, in which case their
+// results are completed at emit time rather than at eviction.
+func (s *store[T]) unreadable() bool {
+	return s.readers.Load() == 0 && (s.declared == nil || s.declared.Load() == 0)
+}
+
+// collect merges the shards into writes in emission order. Callers must hold
+// s.mu.
+func (s *store[T]) collect() {
+	merged := false
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		if len(sh.writes) > 0 {
+			s.writes = append(s.writes, sh.writes...)
+			clear(sh.writes)
+			sh.writes = sh.writes[:0]
+			merged = true
+		}
+		sh.mu.Unlock()
+	}
+	if merged {
+		byStamp := func(a, b slot[T]) int { return cmp.Compare(a.stamp, b.stamp) }
+		if q := s.queue(); !slices.IsSortedFunc(q, byStamp) {
+			slices.SortFunc(q, byStamp)
+		}
+	}
+}
+
+// queue returns the locked writes that were not dropped. Callers must hold
+// s.mu.
+func (s *store[T]) queue() []slot[T] {
+	return s.writes[s.dropped:]
 }
 
-// appendEntry appends an event to the current write buffer and returns its entry.
-func (s *store[T]) appendEntry(v T) *entry[T] {
+// compact removes the dropped writes from the front of the buffer. Callers
+// must hold s.mu.
+func (s *store[T]) compact() {
+	if s.dropped == 0 {
+		return
+	}
+	n := copy(s.writes, s.writes[s.dropped:])
+	clear(s.writes[n:])
+	s.writes = s.writes[:n]
+	s.dropped = 0
+}
+
+// find returns the index in queue of the locked write with the given stamp.
+// Callers must hold s.mu.
+func (s *store[T]) find(stamp uint64) (int, bool) {
+	return slices.BinarySearchFunc(s.queue(), stamp, func(sl slot[T], stamp uint64) int {
+		return cmp.Compare(sl.stamp, stamp)
+	})
+}
+
+// appendKeyed merges v into the pending write emitted under key this frame,
+// or appends a new write registered under key.
+func (s *store[T]) appendKeyed(key any, v T) {
+	if s.suppressed() {
+		return
+	}
 	if s.diag != nil {
 		s.diag.EventEmit(s.name, 1)
 	}
-	ent := s.newEntry(v, false)
 
 	s.mu.Lock()
-	s.writeEnt = append(s.writeEnt, ent)
+	if stamp, ok := s.keys[key]; ok {
+		if i, found := s.find(stamp); found {
+			q := s.queue()
+			if s.keyedMerge != nil {
+				q[i].val = s.keyedMerge(q[i].val, v)
+			} else {
+				q[i].val = v
+			}
+			s.mu.Unlock()
+			return
+		}
+	}
+	sl := slot[T]{val: v}
+	_, dropped, blocked := s.push(&sl, true)
+	if _, found := s.find(sl.stamp); found {
+		if s.keys == nil {
+			s.keys = make(map[any]uint64)
+			s.keyed = make(map[uint64]any)
+		}
+		s.keys[key] = sl.stamp
+		s.keyed[sl.stamp] = key
+	}
 	s.mu.Unlock()
 
-	return ent
+	s.wakeup.notify()
+	s.overflowed(dropped, blocked)
 }
 
-// appendMany appends multiple events without returning result handles.
+// push stamps sl and appends it to the locked write buffer, applying the
+// capacity policy once it is full. It returns the completion state of the
+// write carrying the value (sl's, or that of the pending write it was
+// coalesced into), the completion state of the write dropped to respect the
+// limit, if any, and whether the writer had to wait. Callers must hold s.mu;
+// Block releases it while waiting, or drops sl if wait is false.
+func (s *store[T]) push(sl *slot[T], wait bool) (kept *result, dropped *slot[T], blocked bool) {
+	c := s.capacity
+	if c.Max > 0 {
+		// Count the writes made before the capacity was set.
+		s.collect()
+	}
+	sl.stamp = s.nextStamp()
+	if c.Max <= 0 || len(s.queue()) < c.Max {
+		s.writes = append(s.writes, *sl)
+		return sl.res, nil, false
+	}
+	switch c.Overflow {
+	case DropNewest:
+		return sl.res, sl, false
+	case DropOldest:
+		// Drop by moving the front of the queue; the buffer is compacted by
+		// advance, or here once as many writes were dropped as it holds.
+		oldest := s.writes[s.dropped]
+		s.writes[s.dropped] = slot[T]{}
+		s.dropped++
+		if k, ok := s.keyed[oldest.stamp]; ok {
+			delete(s.keys, k)
+			delete(s.keyed, oldest.stamp)
+		}
+		if s.dropped >= c.Max {
+			s.compact()
+		}
+		s.writes = append(s.writes, *sl)
+		return sl.res, &oldest, false
+	case Coalesce:
+		last := &s.writes[len(s.writes)-1]
+		if c.Coalesce != nil {
+			last.val = c.Coalesce(last.val, sl.val)
+		} else {
+			last.val = sl.val
+		}
+		if last.res == nil {
+			last.res = sl.res
+		}
+		return last.res, nil, false
+	}
+	if !wait {
+		return sl.res, sl, false
+	}
+
+	var timeout <-chan time.Time
+	if c.Timeout > 0 {
+		t := time.NewTimer(c.Timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	for s.capacity.Max > 0 && len(s.queue()) >= s.capacity.Max {
+		if s.room == nil {
+			s.room = make(chan struct{})
+		}
+		room := s.room
+		s.mu.Unlock()
+		select {
+		case <-room:
+			s.mu.Lock()
+		case <-timeout:
+			s.mu.Lock()
+			return sl.res, sl, true
+		}
+	}
+	// Writes stamped while this one waited went first.
+	sl.stamp = s.nextStamp()
+	s.writes = append(s.writes, *sl)
+	return sl.res, nil, true
+}
+
+// overflowed reports a dropped write and a blocked writer, and completes the
+// dropped write.
+func (s *store[T]) overflowed(dropped *slot[T], blocked bool) {
+	n := 0
+	if dropped != nil {
+		n = 1
+	}
+	s.reportOverflow(n, btoi(blocked))
+	if dropped != nil && dropped.res != nil {
+		dropped.res.complete()
+	}
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// reportOverflow reports dropped and blocked writes to diagnostics.
+func (s *store[T]) reportOverflow(dropped, blocked int) {
+	od, ok := s.diag.(OverflowDiagnostics)
+	if !ok {
+		return
+	}
+	if dropped > 0 {
+		od.EventDropped(s.name, dropped)
+	}
+	if blocked > 0 {
+		od.EventBlocked(s.name, blocked)
+	}
+}
+
+// appendMany appends multiple fire-and-forget events.
 func (s *store[T]) appendMany(vals []T) {
-	if len(vals) == 0 {
+	if len(vals) == 0 || s.suppressed() {
 		return
 	}
 
@@ -125,70 +580,271 @@ func (s *store[T]) appendMany(vals []T) {
 		s.diag.EventEmit(s.name, len(vals))
 	}
 
+	if !s.limited.Load() {
+		sh := s.shard()
+		sh.mu.Lock()
+		for _, v := range vals {
+			sh.writes = append(sh.writes, slot[T]{val: v, stamp: s.nextStamp()})
+		}
+		sh.mu.Unlock()
+		s.wakeup.notify()
+		return
+	}
+
+	dropped, blocked := 0, 0
 	s.mu.Lock()
 	for _, v := range vals {
-		s.writeEnt = append(s.writeEnt, s.newEntry(v, false))
+		sl := slot[T]{val: v}
+		_, d, b := s.push(&sl, true)
+		dropped += btoi(d != nil)
+		blocked += btoi(b)
 	}
 	s.mu.Unlock()
+
+	s.wakeup.notify()
+	s.reportOverflow(dropped, blocked)
 }
 
-// drain returns the read values and clears the read buffers.
-// Prefer Reader.Iter for proper completion semantics; Drain is for special cases.
-func (s *store[T]) drain() []T {
+// newReader registers a reader and returns a cursor positioned at the start of
+// the newest readable frame, so a reader created mid-run does not replay older
+// retained frames. The reader counts towards completion of events published
+// from the next advance on.
+func (s *store[T]) newReader() *readerState {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.readers.Add(1)
+	end := s.base + uint64(len(s.vals))
+	if n := len(s.frames); n > 0 {
+		return &readerState{next: s.frames[n-1], since: end}
+	}
+	return &readerState{next: end, since: end}
+}
 
-	if len(s.readEnt) == 0 {
-		return nil
+// closeReader unregisters the reader and releases the published events it
+// has not read yet, as if it had processed them. Closing twice is a no-op.
+func (s *store[T]) closeReader(rs *readerState) {
+	s.mu.Lock()
+	if rs.closed {
+		s.mu.Unlock()
+		return
+	}
+	rs.closed = true
+	s.readers.Add(-1)
+	var release []*result
+	end := s.base + uint64(len(s.vals))
+	for pos := max(rs.next, rs.since, s.base); pos < end; pos++ {
+		if r := s.results[pos-s.base]; r != nil {
+			release = append(release, r)
+		}
 	}
-	out := make([]T, len(s.readEnt))
-	for i, ent := range s.readEnt {
-		out[i] = ent.val
+	rs.next = end
+	s.mu.Unlock()
+	for _, r := range release {
+		r.dec()
 	}
-	// Do not clear readEnt here; CompleteNoReader needs entries to close completion for drained events.
-	// It will be cleared during Advance after completion is ensured.
-	return out
 }
 
-// snapshotEntries returns the current read entries slice without copying.
-// Callers must treat the returned slice as read-only and should not retain it
-// across Advance(), as the store may recycle or mutate entries at frame boundaries.
-func (s *store[T]) snapshotEntries() []*entry[T] {
+// completeNoReader completes every published event if no reader cursor is
+// registered for the type.
+func (s *store[T]) completeNoReader() {
 	s.mu.RLock()
-	out := s.readEnt
+	var results []*result
+	if s.readers.Load() == 0 {
+		for _, r := range s.results {
+			if r != nil {
+				results = append(results, r)
+			}
+		}
+	}
 	s.mu.RUnlock()
-	return out
+	for _, r := range results {
+		r.complete()
+	}
+}
+
+// window is the part of the retained window a cursor has not consumed yet.
+type window[T any] struct {
+	vals    []T
+	flags   []uint32
+	results []*result
+	seqs    []uint64 // nil unless the type is sequenced
+	first   uint64   // sequence number of vals[0] in the store
 }
 
-// advance swaps write/read buffers and clears the new write buffers.
-// Minor perf tweak: reuse slice capacities by slicing to zero length.
-func (s *store[T]) advance() {
+// seq returns the global sequence number of the i-th value, or 0.
+func (w *window[T]) seq(i int) uint64 {
+	if w.seqs == nil {
+		return 0
+	}
+	return w.seqs[i]
+}
+
+// unread returns the values the cursor has not consumed yet, with their
+// flags, results and global sequence numbers. Values evicted before the
+// reader got to them are reported as missed and skipped. Callers must treat
+// the returned slices as read-only, apart from atomic flag updates, and should
+// not retain them across Advance(), as the store compacts the window at frame
+// boundaries.
+func (s *store[T]) unread(rs *readerState) window[T] {
+	s.mu.RLock()
+	if rs.closed {
+		s.mu.RUnlock()
+		return window[T]{first: rs.next}
+	}
+	base := s.base
+	w := window[T]{vals: s.vals, flags: s.flags, results: s.results, seqs: s.seqs}
+	s.mu.RUnlock()
+
+	if rs.next < base {
+		if md, ok := s.diag.(MissDiagnostics); ok {
+			md.EventMissed(s.name, int(base-rs.next))
+		}
+		rs.next = base
+	}
+	end := base + uint64(len(w.vals))
+	if rs.next >= end {
+		return window[T]{first: end}
+	}
+	i := rs.next - base
+	w.vals, w.flags, w.results = w.vals[i:], w.flags[i:], w.results[i:]
+	if w.seqs != nil {
+		w.seqs = w.seqs[i:]
+	}
+	w.first = rs.next
+	return w
+}
+
+// drain returns the unread values for the cursor and marks them as read.
+func (s *store[T]) drain(rs *readerState, limit int) []T {
+	w := s.unread(rs)
+	vals := w.vals
+	if limit >= 0 && len(vals) > limit {
+		vals = vals[:limit]
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	rs.next = w.first + uint64(len(vals))
+	return slices.Clone(vals)
+}
+
+// setRetention changes the number of retained frames. Shrinking takes effect
+// at the next advance.
+func (s *store[T]) setRetention(frames int) {
 	s.mu.Lock()
+	s.retention = max(frames, 1)
+	s.mu.Unlock()
+}
 
-	for _, e := range s.readEnt {
-		if e.state.CompareAndSwap(0, 1) {
-			if e.done != nil {
-				close(e.done)
-			} else {
-				e.doneMu.Lock()
-				if e.done == nil {
-					e.done = closedCh
+func (s *store[T]) customRetention() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.custom
+}
+
+// advance moves the frame's writes into the retained window and evicts frames
+// beyond the retention limit, completing their results. The published values
+// are then delivered to subscriptions and queued on the journal j, if any,
+// outside the lock. frame is the frame ending with this advance. It reports
+// whether any event was published.
+func (s *store[T]) advance(frame uint64, j *Journal) bool {
+	s.mu.Lock()
+	s.collect()
+	s.compact()
+	published := len(s.writes) > 0
+	if published {
+		s.published += uint64(len(s.writes))
+		s.lastEmit = frame
+	}
+
+	var subs []*subscription[T]
+	var sinks []*sink[T]
+	var vals []T
+	var sent []*result
+	record := j != nil && s.journaled
+	if (len(s.subs) > 0 || len(s.sinks) > 0 || record) && len(s.writes) > 0 {
+		subs = slices.Clone(s.subs)
+		sinks = slices.Clone(s.sinks)
+		vals = make([]T, len(s.writes))
+		for i, sl := range s.writes {
+			vals[i] = sl.val
+		}
+		// Links forwarding results hold them until their peer replies.
+		holds := int32(0)
+		for _, sk := range sinks {
+			holds += int32(btoi(sk.results))
+		}
+		if holds > 0 {
+			sent = make([]*result, len(s.writes))
+			for i, sl := range s.writes {
+				if sl.res != nil && !sl.res.IsDone() {
+					sl.res.remote.Add(holds)
+					sent[i] = sl.res
 				}
-				e.doneMu.Unlock()
 			}
 		}
 	}
+	s.frames = append(s.frames, s.base+uint64(len(s.vals)))
+	readers := s.readers.Load()
+	for _, sl := range s.writes {
+		if sl.res != nil && !sl.res.IsDone() {
+			sl.res.pending.Store(readers)
+		}
+		s.vals = append(s.vals, sl.val)
+		s.flags = append(s.flags, 0)
+		s.results = append(s.results, sl.res)
+		if s.sequenced {
+			s.seqs = append(s.seqs, sl.stamp)
+		}
+	}
+	clear(s.keys)
+	clear(s.keyed)
+	clear(s.writes)
+	s.writes = s.writes[:0]
+	if s.room != nil {
+		close(s.room)
+		s.room = nil
+	}
 
-	s.readEnt, s.writeEnt = s.writeEnt, s.readEnt
-
-	if len(s.writeEnt) > 0 {
-		for i := range s.writeEnt {
-			e := s.writeEnt[i]
-			var zero T
-			e.val = zero
-			s.entryPool.Put(e)
+	if drop := len(s.frames) - s.retention; drop > 0 {
+		cut := int(s.frames[drop] - s.base)
+		for _, r := range s.results[:cut] {
+			if r != nil {
+				r.complete()
+			}
+		}
+		n := copy(s.vals, s.vals[cut:])
+		clear(s.vals[n:])
+		s.vals = s.vals[:n]
+		s.flags = s.flags[:copy(s.flags, s.flags[cut:])]
+		n = copy(s.results, s.results[cut:])
+		clear(s.results[n:])
+		s.results = s.results[:n]
+		if s.sequenced {
+			s.seqs = s.seqs[:copy(s.seqs, s.seqs[cut:])]
 		}
-		s.writeEnt = s.writeEnt[:0]
+		s.base += uint64(cut)
+		s.frames = s.frames[:copy(s.frames, s.frames[drop:])]
 	}
 	s.mu.Unlock()
+
+	if record && len(vals) > 0 {
+		j.add(journalName(reflect.TypeFor[T]()), len(vals), func(enc Encoder) error {
+			for _, v := range vals {
+				if err := enc.Encode(v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	for _, sk := range sinks {
+		sk.send(vals, sent)
+	}
+	dropped := 0
+	for _, sub := range subs {
+		dropped += sub.deliver(vals)
+	}
+	s.reportOverflow(dropped, 0)
+	return published
 }
@@ -0,0 +1,205 @@
+package event
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Sequence stamps every event of type T emitted from now on with a number
+// from a sequence shared by all sequenced types of the bus, so events of
+// different types can be ordered by emission, e.g. to tell whether a join
+// happened before a chat message of the same frame. Readers expose it via
+// Seq. Writers of sequenced types contend on one counter, so only sequence
+// the types that need it, before they are emitted; events published earlier
+// get sequence number 0.
+func Sequence[T any](b *Bus) {
+	ensureStore[T](b).sequence(&b.seq)
+}
+
+// Source selects an event type read by a Stream. Create it with Of.
+type Source struct {
+	typ  reflect.Type
+	open func(b *Bus, opts ReaderOptions) part
+}
+
+// Of selects the events of type T for StreamFor. T must be a concrete type;
+// use an interface reader to fan in the implementations of an interface.
+func Of[T any]() Source {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Interface {
+		panic(fmt.Sprintf("event: Of requires a concrete event type, got %s", t))
+	}
+	return Source{typ: t, open: func(b *Bus, opts ReaderOptions) part {
+		st := ensureStore[T](b)
+		st.sequence(&b.seq)
+		return &streamPart[T]{r: Reader[T]{store: st, state: st.newReader(), opts: opts}}
+	}}
+}
+
+// part is the reader of one type of a Stream.
+type part interface {
+	// load takes a snapshot of the unread events.
+	load()
+	// next returns the sequence number of the next loaded event.
+	next() (uint64, bool)
+	// visit yields the next loaded event as a reader would and returns the
+	// result of yield.
+	visit(yield func(any) bool) bool
+	// take returns the next loaded event and marks it as read without
+	// processing it.
+	take() any
+	// reset drops the snapshot.
+	reset()
+	reader() fanSource
+}
+
+type streamPart[T any] struct {
+	r Reader[T]
+	w window[T]
+	i int
+}
+
+func (p *streamPart[T]) load() {
+	p.w, p.i = p.r.store.unread(p.r.state), 0
+}
+
+func (p *streamPart[T]) next() (uint64, bool) {
+	if p.i >= len(p.w.vals) {
+		return 0, false
+	}
+	return p.w.seq(p.i), true
+}
+
+func (p *streamPart[T]) visit(yield func(any) bool) bool {
+	p.i++
+	return p.r.visit(&p.w, p.i-1, func(v *T) bool { return yield(*v) })
+}
+
+func (p *streamPart[T]) take() any {
+	p.i++
+	p.r.state.next = p.w.first + uint64(p.i)
+	return p.w.vals[p.i-1]
+}
+
+func (p *streamPart[T]) reset() {
+	p.w, p.i = window[T]{}, 0
+	p.r.cur = current[T]{}
+}
+
+func (p *streamPart[T]) reader() fanSource {
+	return &p.r
+}
+
+// Stream reads several event types as one stream in emission order, by
+// global sequence number. Each type keeps its own registered cursor, so
+// completion, cancellation, retention and IgnoreCancelled work as for a
+// Reader of that type. A Stream must not be used concurrently.
+type Stream struct {
+	types []reflect.Type
+	parts []part
+	cur   part
+}
+
+// StreamFor returns a stream of the given event types, which it sequences.
+// Systems using it should declare a read access for each type.
+func StreamFor(b *Bus, sources ...Source) *Stream {
+	return StreamWith(b, ReaderOptions{}, sources...)
+}
+
+// StreamWith is like StreamFor but applies the given reader options to
+// every type.
+func StreamWith(b *Bus, opts ReaderOptions, sources ...Source) *Stream {
+	s := &Stream{}
+	for _, src := range sources {
+		s.types = append(s.types, src.typ)
+		s.parts = append(s.parts, src.open(b, opts))
+	}
+	return s
+}
+
+// Types returns the event types of the stream.
+func (s *Stream) Types() []reflect.Type {
+	return s.types
+}
+
+// ForEach yields the unread events of every type in emission order. Use a
+// type switch to tell them apart. The callback returns false to stop early;
+// the remaining events are delivered by the next call. Events published
+// before their type was sequenced come first.
+func (s *Stream) ForEach(yield func(any) bool) {
+	for _, p := range s.parts {
+		p.load()
+	}
+	for {
+		p := s.head()
+		if p == nil {
+			break
+		}
+		s.cur = p
+		if !p.visit(yield) {
+			break
+		}
+	}
+	s.cur = nil
+	for _, p := range s.parts {
+		p.reset()
+	}
+}
+
+// Drain returns the unread events of every type in emission order and marks
+// them as read. Like Reader.Drain, it does not count as processing them.
+func (s *Stream) Drain() []any {
+	var out []any
+	for _, p := range s.parts {
+		p.load()
+	}
+	for p := s.head(); p != nil; p = s.head() {
+		out = append(out, p.take())
+	}
+	for _, p := range s.parts {
+		p.reset()
+	}
+	return out
+}
+
+// head returns the part holding the loaded event with the lowest sequence
+// number, or nil once every part is exhausted. Ties go to the earlier source.
+func (s *Stream) head() part {
+	var best part
+	var lowest uint64
+	for _, p := range s.parts {
+		if seq, ok := p.next(); ok && (best == nil || seq < lowest) {
+			best, lowest = p, seq
+		}
+	}
+	return best
+}
+
+// Cancel marks the current event as cancelled. Call inside ForEach.
+func (s *Stream) Cancel() {
+	if s.cur != nil {
+		s.cur.reader().Cancel()
+	}
+}
+
+// IsCancelled reports whether the current event has been cancelled by any
+// reader.
+func (s *Stream) IsCancelled() bool {
+	return s.cur != nil && s.cur.reader().IsCancelled()
+}
+
+// Seq returns the global sequence number of the current event, or 0 outside
+// ForEach.
+func (s *Stream) Seq() uint64 {
+	if s.cur == nil {
+		return 0
+	}
+	return s.cur.reader().Seq()
+}
+
+// Close closes the reader of every type, like Reader.Close.
+func (s *Stream) Close() {
+	for _, p := range s.parts {
+		p.reader().Close()
+	}
+}
@@ -0,0 +1,171 @@
+package event
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// Overflow selects what a subscription does when its buffer is full.
+type Overflow int
+
+const (
+	// Block makes Advance wait until the subscriber has room, so no event is
+	// lost but a slow subscriber stalls the frame loop.
+	Block Overflow = iota
+	// DropOldest discards the oldest buffered event to make room.
+	DropOldest
+	// DropNewest discards the event being delivered.
+	DropNewest
+	// Coalesce merges the new event into the newest pending one. It only
+	// applies to store capacities; subscriptions treat it as DropOldest.
+	Coalesce
+)
+
+// String returns the overflow policy name.
+func (o Overflow) String() string {
+	switch o {
+	case Block:
+		return "Block"
+	case DropOldest:
+		return "DropOldest"
+	case DropNewest:
+		return "DropNewest"
+	case Coalesce:
+		return "Coalesce"
+	default:
+		return "Unknown"
+	}
+}
+
+// DefaultSubscribeBuffer is the buffer size of subscriptions that do not set
+// one.
+const DefaultSubscribeBuffer = 64
+
+// SubscribeOptions configures a subscription created with Subscribe or
+// SubscribeSeq.
+type SubscribeOptions struct {
+	// Buffer is the number of events buffered for the subscriber. Values
+	// below 1 use DefaultSubscribeBuffer.
+	Buffer int
+	// Overflow is the policy applied when the buffer is full. The zero value
+	// is Block.
+	Overflow Overflow
+}
+
+// subscription delivers published events of one type to a channel.
+type subscription[T any] struct {
+	ch       chan T
+	overflow Overflow
+	done     chan struct{} // closed first on cancellation to release a blocked send
+	mu       sync.Mutex    // serializes sends with closing ch
+	closed   bool
+	once     sync.Once
+}
+
+// Subscribe returns a channel receiving a copy of every event of type T
+// published by Advance, in order, until ctx is cancelled; the channel is then
+// closed. Subscribers are not readers: they cannot cancel events and do not
+// delay completion, but HasReaders reports true while one is active. Use it
+// to feed non-ECS code such as HTTP handlers, loggers or metrics exporters.
+func Subscribe[T any](ctx context.Context, b *Bus, opts SubscribeOptions) <-chan T {
+	_, sub := subscribe[T](ctx, b, opts)
+	return sub.ch
+}
+
+// SubscribeSeq is like Subscribe but returns an iterator. Ranging over it
+// blocks for the next event and ends when ctx is cancelled; breaking out of
+// the loop ends the subscription. The iterator can be ranged over once.
+func SubscribeSeq[T any](ctx context.Context, b *Bus, opts SubscribeOptions) iter.Seq[T] {
+	st, sub := subscribe[T](ctx, b, opts)
+	return func(yield func(T) bool) {
+		defer st.unsubscribe(sub)
+		for v := range sub.ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func subscribe[T any](ctx context.Context, b *Bus, opts SubscribeOptions) (*store[T], *subscription[T]) {
+	st := ensureStore[T](b)
+	buf := opts.Buffer
+	if buf < 1 {
+		buf = DefaultSubscribeBuffer
+	}
+	sub := &subscription[T]{
+		ch:       make(chan T, buf),
+		overflow: opts.Overflow,
+		done:     make(chan struct{}),
+	}
+	st.mu.Lock()
+	st.subs = append(st.subs, sub)
+	st.mu.Unlock()
+	context.AfterFunc(ctx, func() {
+		st.unsubscribe(sub)
+	})
+	return st, sub
+}
+
+// unsubscribe removes sub from the store and closes its channel.
+func (s *store[T]) unsubscribe(sub *subscription[T]) {
+	s.mu.Lock()
+	if i := slices.Index(s.subs, sub); i >= 0 {
+		s.subs = slices.Delete(s.subs, i, i+1)
+	}
+	s.mu.Unlock()
+	sub.close()
+}
+
+func (s *subscription[T]) close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		s.closed = true
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
+
+// deliver sends vals to the subscriber according to its overflow policy and
+// returns the number of events dropped.
+func (s *subscription[T]) deliver(vals []T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dropped := 0
+	for _, v := range vals {
+		if s.closed {
+			return dropped
+		}
+		switch s.overflow {
+		case DropNewest:
+			select {
+			case s.ch <- v:
+			default:
+				dropped++
+			}
+		case DropOldest, Coalesce:
+			for sent := false; !sent; {
+				select {
+				case s.ch <- v:
+					sent = true
+				default:
+					select {
+					case <-s.ch:
+						dropped++
+					default:
+					}
+				}
+			}
+		default:
+			select {
+			case s.ch <- v:
+			case <-s.done:
+				return dropped
+			}
+		}
+	}
+	return dropped
+}
@@ -0,0 +1,202 @@
+package event
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// wheelSlots is the number of slots of a timing wheel.
+const wheelSlots = 256
+
+// Delayed delivery states.
+const (
+	delayPending uint32 = iota
+	delayReleased
+	delayCancelled
+)
+
+// delayed is an event waiting in a timing wheel.
+type delayed struct {
+	tick    uint64
+	seq     uint64 // insertion order within the wheel
+	state   atomic.Uint32
+	release func()
+}
+
+// Scheduled is the handle of an event emitted with EmitAfter or EmitAtFrame.
+// A zero Scheduled is not pending.
+type Scheduled struct {
+	d *delayed
+}
+
+// Cancel prevents the event from being released. It reports whether the
+// event was still pending.
+func (s Scheduled) Cancel() bool {
+	return s.d != nil && s.d.state.CompareAndSwap(delayPending, delayCancelled)
+}
+
+// Pending reports whether the event is still waiting to be released.
+func (s Scheduled) Pending() bool {
+	return s.d != nil && s.d.state.Load() == delayPending
+}
+
+// wheel is a hashed timing wheel: entries are kept in the slot of their tick
+// modulo wheelSlots, and expiring up to a tick only visits the slots passed
+// since the previous expiry. Entries more than one revolution ahead stay in
+// their slot until their tick comes.
+type wheel struct {
+	slots  [wheelSlots][]*delayed
+	cursor uint64 // last expired tick
+	seq    uint64 // last insertion sequence number
+	n      int
+}
+
+// add inserts d. Entries already due go into the next slot to expire.
+func (w *wheel) add(d *delayed) {
+	w.seq++
+	d.seq = w.seq
+	s := &w.slots[max(d.tick, w.cursor+1)%wheelSlots]
+	*s = append(*s, d)
+	w.n++
+}
+
+// expire appends the entries due at or before now to due, in tick order and
+// in insertion order within a tick. Slots are visited in tick order, but
+// when more than one revolution passed, or an entry was added already due,
+// a slot holds entries of several ticks, so the result is sorted.
+func (w *wheel) expire(now uint64, due []*delayed) []*delayed {
+	if now <= w.cursor {
+		return due
+	}
+	if w.n == 0 {
+		w.cursor = now
+		return due
+	}
+	start := len(due)
+	from := w.cursor + 1
+	if now-w.cursor > wheelSlots {
+		from = now - wheelSlots + 1
+	}
+	for t := from; t <= now; t++ {
+		slot := &w.slots[t%wheelSlots]
+		kept := (*slot)[:0]
+		for _, d := range *slot {
+			switch {
+			case d.state.Load() == delayCancelled:
+			case d.tick <= now:
+				due = append(due, d)
+			default:
+				kept = append(kept, d)
+			}
+		}
+		w.n -= len(*slot) - len(kept)
+		clear((*slot)[len(kept):])
+		*slot = kept
+	}
+	w.cursor = now
+	byTick := func(a, b *delayed) int {
+		return cmp.Or(cmp.Compare(a.tick, b.tick), cmp.Compare(a.seq, b.seq))
+	}
+	if expired := due[start:]; !slices.IsSortedFunc(expired, byTick) {
+		slices.SortFunc(expired, byTick)
+	}
+	return due
+}
+
+// next returns the earliest tick of the pending entries.
+func (w *wheel) next() (uint64, bool) {
+	var tick uint64
+	found := false
+	if w.n == 0 {
+		return 0, false
+	}
+	for _, slot := range w.slots {
+		for _, d := range slot {
+			if d.state.Load() == delayPending && (!found || d.tick < tick) {
+				tick, found = d.tick, true
+			}
+		}
+	}
+	return tick, found
+}
+
+// timers releases delayed events into their stores' write buffers at Advance:
+// by frame number for EmitAtFrame and by clock time, in milliseconds, for
+// EmitAfter.
+type timers struct {
+	mu     sync.Mutex
+	frame  uint64
+	now    func() time.Time
+	frames wheel
+	times  wheel
+	due    []*delayed
+}
+
+// clock returns the current time of the bus clock.
+func (t *timers) clock() time.Time {
+	t.mu.Lock()
+	now := t.now
+	t.mu.Unlock()
+	if now == nil {
+		return time.Now()
+	}
+	return now()
+}
+
+// millis converts a time to a wheel tick, rounding up.
+func millis(at time.Time) uint64 {
+	return uint64((at.UnixNano() + int64(time.Millisecond) - 1) / int64(time.Millisecond))
+}
+
+// atFrame schedules release to run when frame n starts. It reports false,
+// without scheduling, if frame n has already started.
+func (t *timers) atFrame(n uint64, release func()) (Scheduled, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n <= t.frame {
+		return Scheduled{}, false
+	}
+	d := &delayed{tick: n, release: release}
+	t.frames.add(d)
+	return Scheduled{d: d}, true
+}
+
+// after schedules release to run at the first Advance at least dur from now.
+func (t *timers) after(dur time.Duration, release func()) Scheduled {
+	d := &delayed{tick: millis(t.clock().Add(dur)), release: release}
+	t.mu.Lock()
+	t.times.add(d)
+	t.mu.Unlock()
+	return Scheduled{d: d}
+}
+
+// advance starts the next frame and releases every event due in it.
+func (t *timers) advance() {
+	now := t.clock()
+	t.mu.Lock()
+	t.frame++
+	due := t.frames.expire(t.frame, t.due[:0])
+	due = t.times.expire(millis(now), due)
+	t.mu.Unlock()
+
+	for _, d := range due {
+		if d.state.CompareAndSwap(delayPending, delayReleased) {
+			d.release()
+		}
+	}
+	clear(due)
+	t.mu.Lock()
+	t.due = due[:0]
+	t.mu.Unlock()
+}
+
+// pendingFrames reports whether an EmitAtFrame event waits for a later frame.
+func (t *timers) pendingFrames() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.frames.next()
+	return ok
+}
@@ -2,6 +2,8 @@ package event
 
 import (
 	"context"
+	"reflect"
+	"time"
 )
 
 // Writer appends events to the current frame's write buffer.
@@ -10,21 +12,22 @@ type Writer[T any] struct {
 	store *store[T]
 }
 
-// Emit appends an event (fire-and-forget).
+// Emit appends an event (fire-and-forget). The event is stored as a plain
+// value, without completion state.
 func (w Writer[T]) Emit(v T) {
 	if w.store == nil {
 		return
 	}
-	_ = w.store.appendEntry(v)
+	w.store.appendValue(v)
 }
 
 // EmitResult appends an event and returns a handle to wait for completion/cancellation.
+// Unlike Emit, it allocates the event's completion state.
 func (w Writer[T]) EmitResult(v T) EventResult[T] {
 	if w.store == nil {
 		return EventResult[T]{}
 	}
-	ent := w.store.appendEntry(v)
-	return EventResult[T]{ent: ent}
+	return EventResult[T]{res: w.store.appendResult(v)}
 }
 
 // EmitAndWait convenience to emit and wait on completion; it returns true if cancelled.
@@ -32,6 +35,52 @@ func (w Writer[T]) EmitAndWait(ctx context.Context, v T) bool {
 	return w.EmitResult(v).Wait(ctx)
 }
 
+// EmitKeyed emits v under key, collapsing repeated events for the same key
+// within a frame into a single entry: a later event is merged into the pending
+// one with the merge function set by SetKeyedMerge, or replaces it if none is
+// set. Keys are compared with ==; merging into a pending entry does not
+// allocate, apart from boxing keys that do not fit an interface directly
+// (pointers and small integers do).
+func (w Writer[T]) EmitKeyed(key any, v T) {
+	if w.store == nil {
+		return
+	}
+	w.store.appendKeyed(key, v)
+}
+
+// EmitAfter emits v at the first Advance at least d from now, measured with
+// the Bus clock: the event enters the write buffer of the frame that Advance
+// starts and is readable from the following one. A d of 0 or less emits
+// right away and returns a zero handle. The returned handle cancels the
+// pending delivery. Released events beyond a Block capacity are dropped, as
+// Advance cannot wait for itself.
+func (w Writer[T]) EmitAfter(d time.Duration, v T) Scheduled {
+	if w.store == nil {
+		return Scheduled{}
+	}
+	if d <= 0 {
+		w.Emit(v)
+		return Scheduled{}
+	}
+	st := w.store
+	return st.timers.after(d, func() { st.release(v) })
+}
+
+// EmitAtFrame emits v when frame n starts, as if Emit was called during frame
+// n (see Bus.Frame). A frame that has already started emits right away and
+// returns a zero handle. The returned handle cancels the pending delivery.
+func (w Writer[T]) EmitAtFrame(n uint64, v T) Scheduled {
+	if w.store == nil {
+		return Scheduled{}
+	}
+	st := w.store
+	s, ok := st.timers.atFrame(n, func() { st.release(v) })
+	if !ok {
+		w.Emit(v)
+	}
+	return s
+}
+
 // EmitMany appends multiple events in a single critical section to reduce contention and allocations.
 // It is safe to pass a nil or empty slice.
 func (w Writer[T]) EmitMany(vals []T) {
@@ -43,44 +92,145 @@ func (w Writer[T]) EmitMany(vals []T) {
 
 // EventResult is a handle to observe completion and cancellation for a single emitted event.
 type EventResult[T any] struct {
-	ent *entry[T]
+	res *result
 }
 
 // Valid reports whether this result is non-zero.
 func (r EventResult[T]) Valid() bool {
-	return r.ent != nil
+	return r.res != nil
 }
 
 // Cancelled reports the current cancellation state without waiting.
 // This may return false even if a reader has not yet had a chance to run.
 func (r EventResult[T]) Cancelled() bool {
-	if r.ent == nil {
+	if r.res == nil {
 		return false
 	}
-	return r.ent.cancelled.Load()
+	return r.res.cancelled.Load()
 }
 
 // Wait blocks until the event has been processed by all readers that started for the frame,
 // or until ctx is done. It returns true if any reader cancelled the event.
 func (r EventResult[T]) Wait(ctx context.Context) bool {
-	if r.ent == nil {
+	if r.res == nil {
 		return false
 	}
 	// Fast path: already done via atomic state.
-	if r.ent.IsDone() {
-		return r.ent.cancelled.Load()
+	if r.res.IsDone() {
+		return r.res.cancelled.Load()
 	}
 
 	// Primary wait using the completion channel for blocking.
-	done := r.ent.ensureDoneChan()
+	done := r.res.ensureDoneChan()
 	select {
 	case <-done:
-		return r.ent.cancelled.Load()
+		return r.res.cancelled.Load()
 	case <-ctx.Done():
 		// If event finishes concurrently with ctx.Done(), prefer the final state via atomic flag.
-		if r.ent.IsDone() {
-			return r.ent.cancelled.Load()
+		if r.res.IsDone() {
+			return r.res.cancelled.Load()
+		}
+		return r.res.cancelled.Load()
+	}
+}
+
+// WaitCancelled blocks until a reader cancels the event, the event completes,
+// or ctx is done. It returns true as soon as cancellation is observed, without
+// waiting for the remaining readers, and false otherwise. As those readers may
+// still write through pointer fields of the event, use Wait when the emitter
+// reads them back.
+func (r EventResult[T]) WaitCancelled(ctx context.Context) bool {
+	if r.res == nil {
+		return false
+	}
+	if r.res.cancelled.Load() {
+		return true
+	}
+	if r.res.IsDone() {
+		return false
+	}
+
+	select {
+	case <-r.res.ensureCancelChan():
+		return true
+	case <-r.res.ensureDoneChan():
+		return r.res.cancelled.Load()
+	case <-ctx.Done():
+		return r.res.cancelled.Load()
+	}
+}
+
+// Done returns a channel that is closed once the event has completed. A zero
+// EventResult returns a closed channel.
+func (r EventResult[T]) Done() <-chan struct{} {
+	if r.res == nil {
+		return closedCh
+	}
+	return r.res.ensureDoneChan()
+}
+
+// OnComplete registers fn to be called with the final cancellation state once
+// the event completes. Callbacks run synchronously on the goroutine completing
+// the event (usually the one calling Bus.Advance), so they must be short and
+// must not block; no goroutine is spawned per event. If the event has already
+// completed, or the result is zero, fn runs immediately on the caller.
+func (r EventResult[T]) OnComplete(fn func(cancelled bool)) {
+	if r.res == nil {
+		fn(false)
+		return
+	}
+	r.res.onComplete(fn)
+}
+
+// Completion is implemented by EventResult[T] for every T, allowing results of
+// different event types to be combined with WaitAll and WaitAny.
+type Completion interface {
+	Done() <-chan struct{}
+	Cancelled() bool
+}
+
+// WaitAll blocks until every result has completed or ctx is done. It reports
+// whether any of them was cancelled, and ctx.Err() if ctx ended first.
+func WaitAll(ctx context.Context, results ...Completion) (cancelled bool, err error) {
+	for _, r := range results {
+		select {
+		case <-r.Done():
+		case <-ctx.Done():
+			return anyCancelled(results), ctx.Err()
+		}
+	}
+	return anyCancelled(results), nil
+}
+
+// WaitAny blocks until one of the results has completed or ctx is done. It
+// returns the index of the first completed result (the lowest index if several
+// are already done), or -1 and ctx.Err() if ctx ended first.
+func WaitAny(ctx context.Context, results ...Completion) (int, error) {
+	for i, r := range results {
+		select {
+		case <-r.Done():
+			return i, nil
+		default:
+		}
+	}
+
+	cases := make([]reflect.SelectCase, 0, len(results)+1)
+	for _, r := range results {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(r.Done())})
+	}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+	i, _, _ := reflect.Select(cases)
+	if i == len(results) {
+		return -1, ctx.Err()
+	}
+	return i, nil
+}
+
+func anyCancelled(results []Completion) bool {
+	for _, r := range results {
+		if r.Cancelled() {
+			return true
 		}
-		return r.ent.cancelled.Load()
 	}
+	return false
 }
@@ -4,15 +4,33 @@ import "slices"
 
 // Conflicts returns true if this access conflicts with another.
 func (a AccessMeta) Conflicts(other AccessMeta) bool {
+	if a.Exclusive || other.Exclusive {
+		return true
+	}
+
+	// Component access of archetype-disjoint systems never touches the same
+	// entities, so only resource and event access can conflict.
+	compDisjoint := a.archetypeDisjoint(other)
+
 	// Fast path: use compact bitsets if available.
 	// Components
-	if a.writesBits != nil && other.readsBits != nil && !a.writesBits.IsDisjoint(other.readsBits) {
-		return true
+	if !compDisjoint {
+		if a.writesBits != nil && other.readsBits != nil && !a.writesBits.IsDisjoint(other.readsBits) {
+			return true
+		}
+		if a.writesBits != nil && other.writesBits != nil && !a.writesBits.IsDisjoint(other.writesBits) {
+			return true
+		}
+		if a.readsBits != nil && other.writesBits != nil && !a.readsBits.IsDisjoint(other.writesBits) {
+			return true
+		}
 	}
-	if a.writesBits != nil && other.writesBits != nil && !a.writesBits.IsDisjoint(other.writesBits) {
+	// Relations
+	if a.relationConflicts(other) || other.relationConflicts(a) {
 		return true
 	}
-	if a.readsBits != nil && other.writesBits != nil && !a.readsBits.IsDisjoint(other.writesBits) {
+	// Event mutation
+	if a.eventMutateConflicts(other) || other.eventMutateConflicts(a) {
 		return true
 	}
 	// Resources
@@ -38,39 +56,41 @@ func (a AccessMeta) Conflicts(other AccessMeta) bool {
 
 	// Fallbacks using precomputed map sets when available (no extra allocations).
 	// Components
-	if other.readsSet != nil {
-		for _, w := range a.Writes {
-			if _, ok := other.readsSet[w]; ok {
-				return true
+	if !compDisjoint {
+		if other.readsSet != nil {
+			for _, w := range a.Writes {
+				if _, ok := other.readsSet[w]; ok {
+					return true
+				}
 			}
-		}
-	} else {
-		for _, w := range a.Writes {
-			if slices.Contains(other.Reads, w) {
-				return true
+		} else {
+			for _, w := range a.Writes {
+				if slices.Contains(other.Reads, w) {
+					return true
+				}
 			}
 		}
-	}
-	if other.writesSet != nil {
-		for _, w := range a.Writes {
-			if _, ok := other.writesSet[w]; ok {
-				return true
+		if other.writesSet != nil {
+			for _, w := range a.Writes {
+				if _, ok := other.writesSet[w]; ok {
+					return true
+				}
 			}
-		}
-		for _, r := range a.Reads {
-			if _, ok := other.writesSet[r]; ok {
-				return true
+			for _, r := range a.Reads {
+				if _, ok := other.writesSet[r]; ok {
+					return true
+				}
 			}
-		}
-	} else {
-		for _, w := range a.Writes {
-			if slices.Contains(other.Writes, w) {
-				return true
+		} else {
+			for _, w := range a.Writes {
+				if slices.Contains(other.Writes, w) {
+					return true
+				}
 			}
-		}
-		for _, r := range a.Reads {
-			if slices.Contains(other.Writes, r) {
-				return true
+			for _, r := range a.Reads {
+				if slices.Contains(other.Writes, r) {
+					return true
+				}
 			}
 		}
 	}
@@ -153,3 +173,89 @@ func (a AccessMeta) Conflicts(other AccessMeta) bool {
 
 	return false
 }
+
+// archetypeDisjoint reports whether the component access of a and other can
+// never match the same archetype: one side requires a component the other
+// excludes. Both With and Without must describe every component access of the
+// respective system for this to be sound.
+func (a AccessMeta) archetypeDisjoint(other AccessMeta) bool {
+	if a.withBits != nil && other.withoutBits != nil && !a.withBits.IsDisjoint(other.withoutBits) {
+		return true
+	}
+	if a.withoutBits != nil && other.withBits != nil && !a.withoutBits.IsDisjoint(other.withBits) {
+		return true
+	}
+	if a.withBits == nil || other.withBits == nil {
+		for _, t := range a.With {
+			if slices.Contains(other.Without, t) {
+				return true
+			}
+		}
+		for _, t := range a.Without {
+			if slices.Contains(other.With, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// relationConflicts reports whether a's relation access conflicts with other's
+// component or relation access. Relation reads conflict with writes of the
+// relation component; relation writes conflict with any access to it. The check
+// is one-directional; Conflicts calls it both ways.
+func (a AccessMeta) relationConflicts(other AccessMeta) bool {
+	if len(a.RelReads) == 0 && len(a.RelWrites) == 0 {
+		return false
+	}
+	overlaps := func(x, y *BitSet) bool {
+		return x != nil && y != nil && !x.IsDisjoint(y)
+	}
+	if overlaps(a.relReadsBits, other.writesBits) ||
+		overlaps(a.relReadsBits, other.relWritesBits) ||
+		overlaps(a.relWritesBits, other.readsBits) ||
+		overlaps(a.relWritesBits, other.writesBits) ||
+		overlaps(a.relWritesBits, other.relReadsBits) ||
+		overlaps(a.relWritesBits, other.relWritesBits) {
+		return true
+	}
+	// Fallback for unprepared metadata.
+	for _, t := range a.RelReads {
+		if slices.Contains(other.Writes, t) || slices.Contains(other.RelWrites, t) {
+			return true
+		}
+	}
+	for _, t := range a.RelWrites {
+		if slices.Contains(other.Reads, t) || slices.Contains(other.Writes, t) ||
+			slices.Contains(other.RelReads, t) || slices.Contains(other.RelWrites, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventMutateConflicts reports whether a's mutating event readers conflict
+// with other's event access. Mutating readers change event values in place, so
+// they conflict with every reader, mutator and writer of the same type. The
+// check is one-directional; Conflicts calls it both ways.
+func (a AccessMeta) eventMutateConflicts(other AccessMeta) bool {
+	if len(a.EventMutates) == 0 {
+		return false
+	}
+	overlaps := func(x, y *BitSet) bool {
+		return x != nil && y != nil && !x.IsDisjoint(y)
+	}
+	if overlaps(a.eventMutatesBits, other.eventReadsBits) ||
+		overlaps(a.eventMutatesBits, other.eventMutatesBits) ||
+		overlaps(a.eventMutatesBits, other.eventWritesBits) {
+		return true
+	}
+	// Fallback for unprepared metadata.
+	for _, t := range a.EventMutates {
+		if slices.Contains(other.EventReads, t) || slices.Contains(other.EventMutates, t) ||
+			slices.Contains(other.EventWrites, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,15 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"reflect"
 	"runtime"
 	"runtime/debug"
 	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -17,6 +21,7 @@ type job struct {
 	sys  *System
 	w    any
 	diag Diagnostics
+	now  func() time.Time
 	wg   *sync.WaitGroup
 }
 
@@ -29,13 +34,30 @@ func (s *systemSorter) Len() int           { return len(s.systems) }
 func (s *systemSorter) Swap(i, j int)      { s.systems[i], s.systems[j] = s.systems[j], s.systems[i] }
 func (s *systemSorter) Less(i, j int) bool { return s.systems[i].Name < s.systems[j].Name }
 
+// ExecMode selects how RunStage executes the systems of a stage.
+type ExecMode int
+
+const (
+	// Parallel runs conflict-free batches concurrently on a worker pool.
+	Parallel ExecMode = iota
+	// Sequential runs every system of a stage one after another in
+	// topological order on the calling goroutine, without a worker pool.
+	Sequential
+)
+
 // Scheduler manages system execution order and parallelization.
 type Scheduler struct {
 	mu        sync.RWMutex
 	systems   map[Stage][]*System
 	batches   map[Stage][][]*System
+	orders    map[Stage][]*System
+	mode      ExecMode
+	now       func() time.Time
 	typeIndex *TypeIndex
 	diag      Diagnostics
+	sync      func(ctx context.Context, w any)
+	oneShots  map[string]*System
+	events    func() []reflect.Type
 
 	// Worker pool
 	maxWorkers    int
@@ -58,6 +80,9 @@ func NewScheduler() *Scheduler {
 	return &Scheduler{
 		systems:    make(map[Stage][]*System),
 		batches:    make(map[Stage][][]*System),
+		oneShots:   make(map[string]*System),
+		orders:     make(map[Stage][]*System),
+		now:        time.Now,
 		typeIndex:  &TypeIndex{},
 		maxWorkers: max(runtime.GOMAXPROCS(0), 1),
 		jobPool: sync.Pool{
@@ -96,17 +121,133 @@ func (s *Scheduler) AddSystem(sys *System) {
 	s.batches[sys.Stage] = nil // Invalidate batches
 }
 
+// SetExecMode selects parallel or sequential execution for subsequent
+// RunStage calls.
+func (s *Scheduler) SetExecMode(mode ExecMode) {
+	s.mu.Lock()
+	s.mode = mode
+	s.mu.Unlock()
+}
+
+// SetClock sets the time source used for Every gating and diagnostics
+// durations. A nil fn restores the wall clock.
+func (s *Scheduler) SetClock(fn func() time.Time) {
+	if fn == nil {
+		fn = time.Now
+	}
+	s.mu.Lock()
+	s.now = fn
+	s.mu.Unlock()
+}
+
+// NextRun returns the earliest time a system gated by Every is due to run
+// again, or false if no system is gated. Gated systems that have not run yet
+// are due immediately.
+func (s *Scheduler) NextRun() (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var next int64
+	found := false
+	for _, systems := range s.systems {
+		for _, sys := range systems {
+			if sys.Meta.Every <= 0 {
+				continue
+			}
+			if n := sys.nextRunUnix.Load(); !found || n < next {
+				next, found = n, true
+			}
+		}
+	}
+	if !found {
+		return time.Time{}, false
+	}
+	return time.Unix(0, next), true
+}
+
+// SetEventTypes sets the source of event types known outside the systems'
+// metadata, such as the types stored on the event bus. Build expands
+// interface event types in access metadata to these types and to the concrete
+// event types other systems declare.
+func (s *Scheduler) SetEventTypes(fn func() []reflect.Type) {
+	s.mu.Lock()
+	s.events = fn
+	s.mu.Unlock()
+}
+
+// AddOneShot registers a system that belongs to no stage and only runs when
+// invoked through RunOneShot. Registering a name twice replaces the system.
+func (s *Scheduler) AddOneShot(sys *System) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sys.Meta.Access.PrepareSets(s.typeIndex)
+	if _, ok := sys.Fn.(func(context.Context, any)); !ok {
+		name := sys.Name
+		sys.Fn = func(context.Context, any) {
+			panic(fmt.Sprintf("invalid system function signature for %s", name))
+		}
+	}
+	s.oneShots[sys.Name] = sys
+}
+
+// ErrUnknownOneShot is returned by RunOneShot for a name with no registered
+// one-shot system. It is not reported to diagnostics, as there is no system
+// to report it for.
+var ErrUnknownOneShot = errors.New("unknown one-shot system")
+
+// RunOneShot runs the named one-shot system on the calling goroutine. The
+// caller must guarantee exclusive access to the world. A panic in the system
+// is recovered and returned as an error.
+func (s *Scheduler) RunOneShot(ctx context.Context, name string, w any) (err error) {
+	s.mu.RLock()
+	sys := s.oneShots[name]
+	now := s.now
+	s.mu.RUnlock()
+	if sys == nil {
+		return fmt.Errorf("%w %q", ErrUnknownOneShot, name)
+	}
+
+	if s.diag != nil {
+		s.diag.SystemStart(sys.Name, sys.Stage)
+	}
+	start := now()
+	defer func() {
+		end := now()
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+		if s.diag != nil {
+			s.diag.SystemEnd(sys.Name, sys.Stage, err, end.Sub(start))
+		}
+		sys.MarkRun(end)
+	}()
+
+	fn := sys.Fn.(func(context.Context, any))
+	fn(ctx, w)
+	return nil
+}
+
 // SetDiagnostics sets the diagnostics implementation.
 func (s *Scheduler) SetDiagnostics(d Diagnostics) {
 	s.diag = d
 }
 
+// SetSyncPoint sets the function applying deferred work (such as recorded
+// commands). It is invoked after every batch in which a system declaring
+// Access.Commands ran, while no other system is running.
+func (s *Scheduler) SetSyncPoint(fn func(ctx context.Context, w any)) {
+	s.sync = fn
+}
+
 // Build computes the execution order and parallel batches for all stages.
 func (s *Scheduler) Build() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.expandEventInterfaces()
+
 	newBatches := make(map[Stage][][]*System, len(s.systems))
+	newOrders := make(map[Stage][]*System, len(s.systems))
 
 	// Process stages in deterministic order
 	stages := make([]Stage, 0, len(s.systems))
@@ -132,17 +273,49 @@ func (s *Scheduler) Build() error {
 		}
 
 		// Validate dependencies first (detect cycles)
-		if _, err := s.topologicalSort(systems); err != nil {
+		order, err := s.topologicalSort(systems)
+		if err != nil {
 			return fmt.Errorf("stage %v: %w", stage, err)
 		}
+		newOrders[stage] = order
 		// Build dependency-aware batches
 		newBatches[stage] = s.computeBatches(systems)
 	}
 	s.batches = newBatches
+	s.orders = newOrders
 
 	return nil
 }
 
+// expandEventInterfaces adds, for every interface event type a system reads or
+// mutates, the event types implementing it, so interface
+// readers conflict with and are ordered against the systems using those
+// types. Callers must hold s.mu.
+func (s *Scheduler) expandEventInterfaces() {
+	all := slices.Collect(maps.Values(s.oneShots))
+	for _, systems := range s.systems {
+		all = append(all, systems...)
+	}
+	var types []reflect.Type
+	if s.events != nil {
+		types = s.events()
+	}
+	for _, sys := range all {
+		acc := &sys.Meta.Access
+		types = append(types, acc.EventReads...)
+		types = append(types, acc.EventWrites...)
+		types = append(types, acc.EventMutates...)
+	}
+	slices.SortFunc(types, func(a, b reflect.Type) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	for _, sys := range all {
+		if sys.Meta.Access.expandEvents(types) {
+			sys.Meta.Access.PrepareSets(s.typeIndex)
+		}
+	}
+}
+
 // Startup initializes the persistent worker pool. It is safe to call multiple times.
 // It is called automatically by the first RunStage execution.
 func (s *Scheduler) Startup() {
@@ -153,7 +326,7 @@ func (s *Scheduler) Startup() {
 			go func() {
 				defer s.workersWG.Done()
 				for j := range s.work {
-					s.runSystem(j.ctx, j.sys, j.w, j.diag)
+					s.runSystem(j.ctx, j.sys, j.w, j.diag, j.now)
 					j.wg.Done()
 					// Reset job and return to pool to avoid allocations.
 					*j = job{} // j.wg is overwritten on next Get, no need to nil it.
@@ -202,6 +375,7 @@ func (s *Scheduler) topologicalSort(systems []*System) ([]*System, error) {
 		}
 	}
 
+	priorityEdges(systems, addEdge)
 	for _, sys := range systems {
 		// sys must run before targets
 		for _, target := range sys.Meta.Before {
@@ -263,6 +437,46 @@ func (s *Scheduler) topologicalSort(systems []*System) ([]*System, error) {
 	return result, nil
 }
 
+// priorityEdges adds ordering edges between systems reading (or mutating) the
+// same event type at different priorities: every reader at one priority runs before the
+// readers at the next higher priority present for that type.
+func priorityEdges(systems []*System, addEdge func(a, b *System)) {
+	type reader struct {
+		sys  *System
+		prio int
+	}
+	var types []reflect.Type
+	readers := make(map[reflect.Type][]reader)
+	for _, sys := range systems {
+		acc := &sys.Meta.Access
+		for _, t := range slices.Concat(acc.EventReads, acc.EventMutates) {
+			rs, seen := readers[t]
+			if !seen {
+				types = append(types, t)
+			}
+			if slices.ContainsFunc(rs, func(r reader) bool { return r.sys == sys }) {
+				continue
+			}
+			readers[t] = append(rs, reader{sys: sys, prio: acc.EventPriorities[t]})
+		}
+	}
+	for _, t := range types {
+		rs := readers[t]
+		slices.SortStableFunc(rs, func(a, b reader) int { return a.prio - b.prio })
+		// prev holds the readers at the previous priority level.
+		var prev, cur []*System
+		for i, r := range rs {
+			if i > 0 && r.prio != rs[i-1].prio {
+				prev, cur = cur, nil
+			}
+			for _, p := range prev {
+				addEdge(p, r.sys)
+			}
+			cur = append(cur, r.sys)
+		}
+	}
+}
+
 // computeBatches groups systems into parallel batches based on access conflicts
 // while respecting Before/After constraints using DAG levels.
 func (s *Scheduler) computeBatches(systems []*System) [][]*System {
@@ -286,6 +500,7 @@ func (s *Scheduler) computeBatches(systems []*System) [][]*System {
 			s.inDegree[b]++
 		}
 	}
+	priorityEdges(systems, addDep)
 	for _, sys := range systems {
 		// sys must run after deps
 		for _, dep := range sys.Meta.After {
@@ -417,13 +632,21 @@ type Diagnostics interface {
 
 // RunStage executes all systems for the given stage.
 func (s *Scheduler) RunStage(ctx context.Context, stage Stage, w any) {
-	// Ensure the worker pool is running. This is safe to call multiple times
-	s.Startup()
-
 	s.mu.RLock()
+	mode := s.mode
 	batches := s.batches[stage]
+	order := s.orders[stage]
+	now := s.now
 	s.mu.RUnlock()
 
+	if mode == Sequential {
+		s.runSequential(ctx, order, w, now)
+		return
+	}
+
+	// Ensure the worker pool is running. This is safe to call multiple times
+	s.Startup()
+
 	for _, batch := range batches {
 		// Allow cancellation between batches
 		if err := ctx.Err(); err != nil {
@@ -435,35 +658,61 @@ func (s *Scheduler) RunStage(ctx context.Context, stage Stage, w any) {
 		sort.Sort(s.sorter)
 
 		batchWG := s.waitGroupPool.Get().(*sync.WaitGroup)
+		deferred := false
 		for _, sys := range batch {
-			if !sys.ShouldRun(time.Now()) {
+			if !sys.ShouldRun(now()) {
 				continue
 			}
+			deferred = deferred || sys.Meta.Access.Commands
 			batchWG.Add(1)
 			j := s.jobPool.Get().(*job)
 			j.ctx = ctx
 			j.sys = sys
 			j.w = w
 			j.diag = s.diag
+			j.now = now
 			j.wg = batchWG
 			s.work <- j
 		}
 		batchWG.Wait()
 		s.waitGroupPool.Put(batchWG)
+
+		if deferred && s.sync != nil {
+			s.sync(ctx, w)
+		}
+	}
+}
+
+// runSequential runs the systems of a stage in topological order on the
+// calling goroutine. Deferred work is applied right after each system that
+// declares Access.Commands.
+func (s *Scheduler) runSequential(ctx context.Context, order []*System, w any, now func() time.Time) {
+	for _, sys := range order {
+		if err := ctx.Err(); err != nil {
+			return
+		}
+		if !sys.ShouldRun(now()) {
+			continue
+		}
+		s.runSystem(ctx, sys, w, s.diag, now)
+		if sys.Meta.Access.Commands && s.sync != nil {
+			s.sync(ctx, w)
+		}
 	}
 }
 
 // runSystem executes a single system with diagnostics and error handling.
-func (s *Scheduler) runSystem(ctx context.Context, sys *System, w any, diag Diagnostics) {
+// now is the clock read by the caller once per stage.
+func (s *Scheduler) runSystem(ctx context.Context, sys *System, w any, diag Diagnostics, now func() time.Time) {
 	if diag != nil {
 		diag.SystemStart(sys.Name, sys.Stage)
 	}
 
-	start := time.Now()
+	start := now()
 	var runErr error
 
 	defer func() {
-		end := time.Now()
+		end := now()
 
 		r := recover()
 		if r != nil {
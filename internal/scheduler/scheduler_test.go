@@ -2,7 +2,10 @@ package scheduler_test
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -12,6 +15,7 @@ import (
 
 const Startup = scheduler.Stage(0)
 const Update = scheduler.Stage(1)
+const OneShot = scheduler.Stage(6)
 
 // Test that stages isolate systems and that Before/After ordering constraints
 // are honored in a single RunStage execution.
@@ -317,3 +321,476 @@ func TestZeroEveryAndLoad(t *testing.T) {
 		t.Fatalf("Baseline ran %d times, want %d", got, frames)
 	}
 }
+
+// Test that the sync point runs after a batch containing a system with
+// deferred commands, before systems ordered after it, and that exclusive
+// systems never share a batch.
+func TestSyncPointAfterCommandsBatch(t *testing.T) {
+	s := scheduler.NewScheduler()
+
+	var order []string
+	record := func(name string) func(context.Context, any) {
+		return func(ctx context.Context, _ any) { order = append(order, name) }
+	}
+	s.SetSyncPoint(func(context.Context, any) { order = append(order, "sync") })
+
+	s.AddSystem(&scheduler.System{
+		Name:  "Spawner",
+		Stage: Update,
+		Fn:    record("Spawner"),
+		Meta:  scheduler.SystemMeta{Access: scheduler.AccessMeta{Commands: true}},
+	})
+	s.AddSystem(&scheduler.System{
+		Name:  "Reader",
+		Stage: Update,
+		Fn:    record("Reader"),
+		Meta:  scheduler.SystemMeta{After: []string{"Spawner"}},
+	})
+	s.AddSystem(&scheduler.System{
+		Name:  "Plain",
+		Stage: Update,
+		Fn:    record("Plain"),
+		Meta:  scheduler.SystemMeta{After: []string{"Reader"}},
+	})
+
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	s.RunStage(context.Background(), Update, &struct{}{})
+
+	want := []string{"Spawner", "sync", "Reader", "Plain"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order[%d] = %q, want %q; full order: %v", i, order[i], want[i], order)
+		}
+	}
+
+	excl := scheduler.AccessMeta{Exclusive: true}
+	if !excl.Conflicts(scheduler.AccessMeta{}) || !(scheduler.AccessMeta{}).Conflicts(excl) {
+		t.Fatalf("exclusive access must conflict with everything")
+	}
+}
+
+// Test that the sync point applying deferred commands never overlaps a
+// system, even one reading the components being spawned that shares a batch
+// with the system recording them. This is why Commands need no component or
+// resource access of their own.
+func TestSyncPointSerializesReaders(t *testing.T) {
+	s := scheduler.NewScheduler()
+	posType := reflect.TypeOf((*struct{ X float64 })(nil)).Elem()
+
+	var active, syncs, overlaps atomic.Int32
+	run := func(context.Context, any) {
+		active.Add(1)
+		time.Sleep(time.Millisecond)
+		active.Add(-1)
+	}
+	s.SetSyncPoint(func(context.Context, any) {
+		syncs.Add(1)
+		if active.Load() != 0 {
+			overlaps.Add(1)
+		}
+	})
+
+	s.AddSystem(&scheduler.System{
+		Name:  "Spawner",
+		Stage: Update,
+		Fn:    run,
+		Meta:  scheduler.SystemMeta{Access: scheduler.AccessMeta{Commands: true}},
+	})
+	for _, name := range []string{"ReaderA", "ReaderB", "ReaderC"} {
+		s.AddSystem(&scheduler.System{
+			Name:  name,
+			Stage: Update,
+			Fn:    run,
+			Meta:  scheduler.SystemMeta{Access: scheduler.AccessMeta{Reads: []reflect.Type{posType}}},
+		})
+	}
+
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	for range 20 {
+		s.RunStage(context.Background(), Update, &struct{}{})
+	}
+	if syncs.Load() == 0 {
+		t.Fatalf("sync point never ran")
+	}
+	if n := overlaps.Load(); n != 0 {
+		t.Fatalf("sync point overlapped running systems %d times", n)
+	}
+}
+
+// Test that systems writing the same component through archetype-disjoint
+// filters (With vs Without the same marker) do not conflict, while
+// overlapping filters still do.
+func TestFilterDisjointAccess(t *testing.T) {
+	posType := reflect.TypeOf((*struct{ X float64 })(nil)).Elem()
+	playerType := reflect.TypeOf((*struct{ Player bool })(nil)).Elem()
+
+	players := scheduler.AccessMeta{
+		Writes: []reflect.Type{posType},
+		With:   []reflect.Type{posType, playerType},
+	}
+	npcs := scheduler.AccessMeta{
+		Writes:  []reflect.Type{posType},
+		With:    []reflect.Type{posType},
+		Without: []reflect.Type{playerType},
+	}
+	all := scheduler.AccessMeta{
+		Writes: []reflect.Type{posType},
+		With:   []reflect.Type{posType},
+	}
+
+	// Unprepared (slice fallback) path.
+	if players.Conflicts(npcs) || npcs.Conflicts(players) {
+		t.Fatalf("disjoint filters must not conflict")
+	}
+	if !all.Conflicts(npcs) || !players.Conflicts(all) {
+		t.Fatalf("overlapping filters must conflict")
+	}
+
+	// Prepared (bitset) path.
+	ti := &scheduler.TypeIndex{}
+	for _, acc := range []*scheduler.AccessMeta{&players, &npcs, &all} {
+		acc.PrepareSets(ti)
+	}
+	if players.Conflicts(npcs) || npcs.Conflicts(players) {
+		t.Fatalf("disjoint filters must not conflict after PrepareSets")
+	}
+	if !all.Conflicts(npcs) || !players.Conflicts(all) {
+		t.Fatalf("overlapping filters must conflict after PrepareSets")
+	}
+}
+
+func TestRelationAccess(t *testing.T) {
+	childOf := reflect.TypeOf((*struct{ ChildOf bool })(nil)).Elem()
+	posType := reflect.TypeOf((*struct{ X float64 })(nil)).Elem()
+
+	traverse := scheduler.AccessMeta{RelReads: []reflect.Type{childOf}}
+	traverse2 := scheduler.AccessMeta{RelReads: []reflect.Type{childOf}}
+	reparent := scheduler.AccessMeta{RelWrites: []reflect.Type{childOf}}
+	readRel := scheduler.AccessMeta{Reads: []reflect.Type{childOf}}
+	move := scheduler.AccessMeta{
+		Writes:  []reflect.Type{posType},
+		With:    []reflect.Type{posType},
+		Without: []reflect.Type{childOf},
+	}
+	reparentFiltered := scheduler.AccessMeta{
+		RelWrites: []reflect.Type{childOf},
+		Reads:     []reflect.Type{childOf},
+		With:      []reflect.Type{childOf},
+	}
+
+	check := func(stage string) {
+		t.Helper()
+		if traverse.Conflicts(traverse2) {
+			t.Fatalf("%s: relation reads must not conflict", stage)
+		}
+		if !traverse.Conflicts(reparent) || !reparent.Conflicts(traverse) {
+			t.Fatalf("%s: relation read and write must conflict", stage)
+		}
+		if !reparent.Conflicts(readRel) || !readRel.Conflicts(reparent) {
+			t.Fatalf("%s: relation write and component read must conflict", stage)
+		}
+		if traverse.Conflicts(readRel) {
+			t.Fatalf("%s: relation read and component read must not conflict", stage)
+		}
+		if reparentFiltered.Conflicts(move) || move.Conflicts(reparentFiltered) {
+			t.Fatalf("%s: unrelated access must not conflict", stage)
+		}
+	}
+
+	check("slices")
+	ti := &scheduler.TypeIndex{}
+	for _, acc := range []*scheduler.AccessMeta{&traverse, &traverse2, &reparent, &readRel, &move, &reparentFiltered} {
+		acc.PrepareSets(ti)
+	}
+	check("bitsets")
+}
+
+// Test that one-shot systems never run as part of a stage and that
+// RunOneShot reports unknown names and recovered panics as errors.
+func TestOneShotSystems(t *testing.T) {
+	s := scheduler.NewScheduler()
+
+	var runs atomic.Int32
+	s.AddOneShot(&scheduler.System{
+		Name:  "Grant",
+		Stage: OneShot,
+		Fn:    func(context.Context, any) { runs.Add(1) },
+		Meta:  scheduler.SystemMeta{Access: scheduler.AccessMeta{Commands: true}},
+	})
+	s.AddOneShot(&scheduler.System{
+		Name:  "Broken",
+		Stage: OneShot,
+		Fn:    func(context.Context, any) { panic("boom") },
+	})
+
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	s.RunStage(context.Background(), Update, &struct{}{})
+	if runs.Load() != 0 {
+		t.Fatalf("one-shot system ran as part of a stage")
+	}
+
+	if err := s.RunOneShot(context.Background(), "Grant", &struct{}{}); err != nil {
+		t.Fatalf("RunOneShot failed: %v", err)
+	}
+	if runs.Load() != 1 {
+		t.Fatalf("expected 1 run, got %d", runs.Load())
+	}
+	if err := s.RunOneShot(context.Background(), "Missing", &struct{}{}); !errors.Is(err, scheduler.ErrUnknownOneShot) {
+		t.Fatalf("expected ErrUnknownOneShot for unknown one-shot system, got %v", err)
+	}
+	if err := s.RunOneShot(context.Background(), "Broken", &struct{}{}); err == nil {
+		t.Fatalf("expected error for panicking one-shot system")
+	}
+}
+
+// Test that Sequential mode runs systems in topological order, applies
+// deferred work right after systems declaring Commands, and gates Every on
+// the injected clock.
+func TestSequentialMode(t *testing.T) {
+	s := scheduler.NewScheduler()
+	s.SetExecMode(scheduler.Sequential)
+
+	now := time.Unix(0, 0)
+	s.SetClock(func() time.Time { return now })
+
+	var order []string
+	record := func(name string) func(context.Context, any) {
+		return func(ctx context.Context, _ any) { order = append(order, name) }
+	}
+	s.SetSyncPoint(func(context.Context, any) { order = append(order, "sync") })
+
+	s.AddSystem(&scheduler.System{Name: "C", Stage: Update, Fn: record("C")})
+	s.AddSystem(&scheduler.System{
+		Name:  "B",
+		Stage: Update,
+		Fn:    record("B"),
+		Meta:  scheduler.SystemMeta{Before: []string{"C"}, Access: scheduler.AccessMeta{Commands: true}},
+	})
+	s.AddSystem(&scheduler.System{
+		Name:  "A",
+		Stage: Update,
+		Fn:    record("A"),
+		Meta:  scheduler.SystemMeta{After: []string{"C"}, Every: time.Second},
+	})
+
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	run := func() []string {
+		order = order[:0]
+		s.RunStage(context.Background(), Update, &struct{}{})
+		return append([]string(nil), order...)
+	}
+
+	want := []string{"B", "sync", "C", "A"}
+	if got := run(); !slices.Equal(got, want) {
+		t.Fatalf("first frame: expected %v, got %v", want, got)
+	}
+	now = now.Add(500 * time.Millisecond)
+	want = []string{"B", "sync", "C"}
+	if got := run(); !slices.Equal(got, want) {
+		t.Fatalf("gated frame: expected %v, got %v", want, got)
+	}
+	now = now.Add(500 * time.Millisecond)
+	want = []string{"B", "sync", "C", "A"}
+	if got := run(); !slices.Equal(got, want) {
+		t.Fatalf("due frame: expected %v, got %v", want, got)
+	}
+}
+
+func TestEventPriorityOrdering(t *testing.T) {
+	s := scheduler.NewScheduler()
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func(context.Context, any) {
+		return func(ctx context.Context, _ any) {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+	chat := reflect.TypeOf(struct{ Msg string }{})
+	reader := func(name string, prio int) *scheduler.System {
+		return &scheduler.System{
+			Name:  name,
+			Stage: Update,
+			Fn:    record(name),
+			Meta: scheduler.SystemMeta{Access: scheduler.AccessMeta{
+				EventReads:      []reflect.Type{chat},
+				EventPriorities: map[reflect.Type]int{chat: prio},
+			}},
+		}
+	}
+	// Registered out of order; names sort against priority.
+	s.AddSystem(reader("a_monitor", 3))
+	s.AddSystem(reader("b_high", 1))
+	s.AddSystem(reader("c_normal", 0))
+	s.AddSystem(reader("d_normal", 0))
+	s.AddSystem(reader("e_lowest", -2))
+
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	s.RunStage(context.Background(), Update, &struct{}{})
+
+	pos := func(name string) int { return slices.Index(order, name) }
+	if len(order) != 5 {
+		t.Fatalf("expected 5 systems to run, got %v", order)
+	}
+	if !(pos("e_lowest") < pos("c_normal") && pos("e_lowest") < pos("d_normal") &&
+		pos("c_normal") < pos("b_high") && pos("d_normal") < pos("b_high") &&
+		pos("b_high") < pos("a_monitor")) {
+		t.Fatalf("readers ran out of priority order: %v", order)
+	}
+}
+
+func TestEventMutateAccess(t *testing.T) {
+	chat := reflect.TypeOf((*struct{ Msg string })(nil)).Elem()
+	other := reflect.TypeOf((*struct{ N int })(nil)).Elem()
+
+	read := scheduler.AccessMeta{EventReads: []reflect.Type{chat}}
+	read2 := scheduler.AccessMeta{EventReads: []reflect.Type{chat}}
+	mutate := scheduler.AccessMeta{EventMutates: []reflect.Type{chat}}
+	mutate2 := scheduler.AccessMeta{EventMutates: []reflect.Type{chat}}
+	write := scheduler.AccessMeta{EventWrites: []reflect.Type{chat}}
+	mutateOther := scheduler.AccessMeta{EventMutates: []reflect.Type{other}}
+
+	check := func(stage string) {
+		t.Helper()
+		if read.Conflicts(read2) {
+			t.Fatalf("%s: event reads must not conflict", stage)
+		}
+		if !mutate.Conflicts(read) || !read.Conflicts(mutate) {
+			t.Fatalf("%s: mutate and read must conflict", stage)
+		}
+		if !mutate.Conflicts(mutate2) {
+			t.Fatalf("%s: mutators must conflict", stage)
+		}
+		if !mutate.Conflicts(write) || !write.Conflicts(mutate) {
+			t.Fatalf("%s: mutate and write must conflict", stage)
+		}
+		if mutateOther.Conflicts(read) || read.Conflicts(mutateOther) {
+			t.Fatalf("%s: mutators of other types must not conflict", stage)
+		}
+	}
+
+	check("slices")
+	ti := &scheduler.TypeIndex{}
+	for _, acc := range []*scheduler.AccessMeta{&read, &read2, &mutate, &mutate2, &write, &mutateOther} {
+		acc.PrepareSets(ti)
+	}
+	check("bitsets")
+}
+
+type playerEvent interface{ player() string }
+
+type chatEvent struct{ Name string }
+
+func (e chatEvent) player() string { return e.Name }
+
+type quitEvent struct{ Name string }
+
+func (e quitEvent) player() string { return e.Name }
+
+func TestEventInterfaceExpansion(t *testing.T) {
+	s := scheduler.NewScheduler()
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func(context.Context, any) {
+		return func(ctx context.Context, _ any) {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+	iface := reflect.TypeOf((*playerEvent)(nil)).Elem()
+	chat := reflect.TypeOf(chatEvent{})
+	quit := reflect.TypeOf(quitEvent{})
+	other := reflect.TypeOf(struct{ N int }{})
+
+	audit := &scheduler.System{
+		Name:  "a_audit",
+		Stage: Update,
+		Fn:    record("a_audit"),
+		Meta: scheduler.SystemMeta{Access: scheduler.AccessMeta{
+			EventReads:      []reflect.Type{iface},
+			EventPriorities: map[reflect.Type]int{iface: 3},
+		}},
+	}
+	s.AddSystem(audit)
+	s.AddSystem(&scheduler.System{
+		Name:  "b_chat",
+		Stage: Update,
+		Fn:    record("b_chat"),
+		Meta: scheduler.SystemMeta{Access: scheduler.AccessMeta{
+			EventReads: []reflect.Type{chat, other},
+		}},
+	})
+	// quitEvent is only known to the event bus.
+	s.SetEventTypes(func() []reflect.Type { return []reflect.Type{quit, other} })
+
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	acc := audit.Meta.Access
+	if !slices.Contains(acc.EventReads, chat) || !slices.Contains(acc.EventReads, quit) ||
+		slices.Contains(acc.EventReads, other) {
+		t.Fatalf("expected the interface to expand to its implementers, got %v", acc.EventReads)
+	}
+	if acc.EventPriorities[chat] != 3 || acc.EventPriorities[quit] != 3 {
+		t.Fatalf("expected expanded types to keep the interface priority, got %v", acc.EventPriorities)
+	}
+
+	// The monitor-level interface reader runs after the concrete reader.
+	s.RunStage(context.Background(), Update, &struct{}{})
+	if !slices.Equal(order, []string{"b_chat", "a_audit"}) {
+		t.Fatalf("got order %v, want [b_chat a_audit]", order)
+	}
+
+	// Building again does not duplicate expanded types.
+	n := len(audit.Meta.Access.EventReads)
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(audit.Meta.Access.EventReads) != n {
+		t.Fatalf("expected a stable expansion, got %v", audit.Meta.Access.EventReads)
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	s := scheduler.NewScheduler()
+	now := time.Unix(1000, 0)
+	s.SetClock(func() time.Time { return now })
+	if _, ok := s.NextRun(); ok {
+		t.Fatal("no gated system, expected no deadline")
+	}
+	for _, every := range []time.Duration{0, time.Second, 300 * time.Millisecond} {
+		s.AddSystem(&scheduler.System{
+			Name:  every.String(),
+			Stage: Update,
+			Fn:    func(context.Context, any) {},
+			Meta:  scheduler.SystemMeta{Every: every},
+		})
+	}
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if next, ok := s.NextRun(); !ok || next.After(now) {
+		t.Fatalf("systems that never ran should be due, got %v %v", next, ok)
+	}
+	s.RunStage(context.Background(), Update, nil)
+	if next, ok := s.NextRun(); !ok || !next.Equal(now.Add(300*time.Millisecond)) {
+		t.Fatalf("expected the earliest deadline, got %v %v", next, ok)
+	}
+}
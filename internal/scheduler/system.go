@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"reflect"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,6 +29,36 @@ type AccessMeta struct {
 	EventReads  []reflect.Type
 	EventWrites []reflect.Type
 
+	// EventMutates lists event types the system reads and modifies in place
+	// (Reader.ForEachMut). They conflict with every other reader, mutator and
+	// writer of the type.
+	EventMutates []reflect.Type
+
+	// EventPriorities holds the reader priority for event types in
+	// EventReads or EventMutates; missing types have priority 0. Systems reading the same
+	// event type run in ascending priority order.
+	EventPriorities map[reflect.Type]int
+
+	// With and Without describe the archetype filter shared by all component
+	// access of the system: every entity it reads or writes has all With
+	// components and none of the Without components. Systems whose filters are
+	// provably disjoint do not conflict on component access.
+	With    []reflect.Type
+	Without []reflect.Type
+
+	// RelReads and RelWrites list relation components whose targets the system
+	// reads (relation queries, hierarchy traversal) or changes (SetRelations,
+	// adding relation components). Relation writes move entities between
+	// tables, so relation access ignores archetype filters.
+	RelReads  []reflect.Type
+	RelWrites []reflect.Type
+
+	// Commands marks a system that records deferred structural changes; the
+	// scheduler runs the sync point after the batch containing it.
+	Commands bool
+	// Exclusive marks a system that conflicts with every other system.
+	Exclusive bool
+
 	// Precomputed sets for fast conflict checks
 	readsSet       map[reflect.Type]struct{}
 	writesSet      map[reflect.Type]struct{}
@@ -37,12 +68,17 @@ type AccessMeta struct {
 	eventWritesSet map[reflect.Type]struct{}
 
 	// Compact bitset representation using a TypeIndex
-	readsBits       *BitSet
-	writesBits      *BitSet
-	resReadsBits    *BitSet
-	resWritesBits   *BitSet
-	eventReadsBits  *BitSet
-	eventWritesBits *BitSet
+	readsBits        *BitSet
+	writesBits       *BitSet
+	resReadsBits     *BitSet
+	resWritesBits    *BitSet
+	eventReadsBits   *BitSet
+	eventWritesBits  *BitSet
+	eventMutatesBits *BitSet
+	withBits         *BitSet
+	withoutBits      *BitSet
+	relReadsBits     *BitSet
+	relWritesBits    *BitSet
 }
 
 // PrepareSets precomputes lookup sets from the slice fields for faster conflict checks.
@@ -84,6 +120,42 @@ func (a *AccessMeta) PrepareSets(ti *TypeIndex) {
 	a.resWritesBits = buildBits(a.ResWrites)
 	a.eventReadsBits = buildBits(a.EventReads)
 	a.eventWritesBits = buildBits(a.EventWrites)
+	a.eventMutatesBits = buildBits(a.EventMutates)
+	a.withBits = buildBits(a.With)
+	a.withoutBits = buildBits(a.Without)
+	a.relReadsBits = buildBits(a.RelReads)
+	a.relWritesBits = buildBits(a.RelWrites)
+}
+
+// expandEvents adds the types among types that implement the interface types
+// of EventReads and EventMutates, with the interface's priority. It reports
+// whether anything was added.
+func (a *AccessMeta) expandEvents(types []reflect.Type) bool {
+	expand := func(dst []reflect.Type) ([]reflect.Type, bool) {
+		added := false
+		for _, i := range dst {
+			if i.Kind() != reflect.Interface {
+				continue
+			}
+			for _, t := range types {
+				if t == i || !t.Implements(i) || slices.Contains(dst, t) {
+					continue
+				}
+				dst = append(dst, t)
+				added = true
+				if p, ok := a.EventPriorities[i]; ok {
+					if _, set := a.EventPriorities[t]; !set {
+						a.EventPriorities[t] = p
+					}
+				}
+			}
+		}
+		return dst, added
+	}
+	var r, m bool
+	a.EventReads, r = expand(a.EventReads)
+	a.EventMutates, m = expand(a.EventMutates)
+	return r || m
 }
 
 // System represents a registered system with its metadata.
@@ -1,9 +1,12 @@
 package bevi
 
 import (
+	"maps"
 	"reflect"
+	"slices"
 	"time"
 
+	"github.com/oriumgames/bevi/internal/event"
 	"github.com/oriumgames/bevi/internal/scheduler"
 )
 
@@ -15,6 +18,36 @@ type AccessMeta struct {
 	ResWrites   []reflect.Type
 	EventReads  []reflect.Type
 	EventWrites []reflect.Type
+
+	// EventMutates lists event types the system modifies in place through
+	// EventReader.ForEachMut. Such systems run apart from every other reader
+	// and writer of the type.
+	EventMutates []reflect.Type
+
+	// EventPriorities sets the reader priority of event types in EventReads
+	// and EventMutates.
+	// Systems reading the same event type run from PriorityLowest to
+	// PriorityMonitor; types without an entry are PriorityNormal.
+	EventPriorities map[reflect.Type]EventPriority
+
+	// With and Without describe the archetype filter shared by every component
+	// read and write of the system. Systems whose filters are provably disjoint
+	// (one requires a component the other excludes) may run in parallel even if
+	// they write the same component types.
+	With    []reflect.Type
+	Without []reflect.Type
+
+	// RelReads and RelWrites list relation components whose targets the system
+	// reads (relation queries, hierarchy traversal) or changes (SetRelations,
+	// adding or removing relation components).
+	RelReads  []reflect.Type
+	RelWrites []reflect.Type
+
+	// Commands marks a system that records deferred world changes through
+	// Commands. The scheduler inserts a sync point after its batch.
+	Commands bool
+	// Exclusive marks a system that must not run alongside any other system.
+	Exclusive bool
 }
 
 // NewAccess creates a new empty AccessMeta.
@@ -65,24 +98,136 @@ func AccessEventWrite[E any](acc *AccessMeta) {
 	acc.EventWrites = append(acc.EventWrites, typ)
 }
 
-// MergeAccess merges src into dst.
+// AccessEventMutate adds an event access that reads and modifies events in
+// place (EventReader.ForEachMut).
+func AccessEventMutate[E any](acc *AccessMeta) {
+	typ := reflect.TypeOf((*E)(nil)).Elem()
+	acc.EventMutates = append(acc.EventMutates, typ)
+}
+
+// AccessEventPriority sets the reader priority for event type E. Use it along
+// with AccessEventRead or AccessEventMutate.
+func AccessEventPriority[E any](acc *AccessMeta, p EventPriority) {
+	typ := reflect.TypeOf((*E)(nil)).Elem()
+	if acc.EventPriorities == nil {
+		acc.EventPriorities = make(map[reflect.Type]EventPriority)
+	}
+	acc.EventPriorities[typ] = p
+}
+
+// AccessRequestRead adds a read access for requests T answered with R.
+func AccessRequestRead[T, R any](acc *AccessMeta) {
+	acc.EventReads = append(acc.EventReads, event.RequestType[T, R]())
+}
+
+// AccessRequestWrite adds a write access for requests T answered with R.
+func AccessRequestWrite[T, R any](acc *AccessMeta) {
+	acc.EventWrites = append(acc.EventWrites, event.RequestType[T, R]())
+}
+
+// AccessRequestPriority sets the reader priority for requests T answered with
+// R. Use it along with AccessRequestRead.
+func AccessRequestPriority[T, R any](acc *AccessMeta, p EventPriority) {
+	if acc.EventPriorities == nil {
+		acc.EventPriorities = make(map[reflect.Type]EventPriority)
+	}
+	acc.EventPriorities[event.RequestType[T, R]()] = p
+}
+
+// AccessWith declares that all component access of the system is restricted
+// to entities that have component T.
+func AccessWith[T any](acc *AccessMeta) {
+	typ := baseType(reflect.TypeOf((*T)(nil)).Elem())
+	acc.With = append(acc.With, typ)
+}
+
+// AccessWithout declares that all component access of the system is
+// restricted to entities that do not have component T.
+func AccessWithout[T any](acc *AccessMeta) {
+	typ := baseType(reflect.TypeOf((*T)(nil)).Elem())
+	acc.Without = append(acc.Without, typ)
+}
+
+// AccessRelRead declares that the system reads the targets of relation
+// component T, e.g. by querying with bevi.Rel[T](parent).
+func AccessRelRead[T any](acc *AccessMeta) {
+	typ := baseType(reflect.TypeOf((*T)(nil)).Elem())
+	acc.RelReads = append(acc.RelReads, typ)
+}
+
+// AccessRelWrite declares that the system changes the targets of relation
+// component T, e.g. by re-parenting entities.
+func AccessRelWrite[T any](acc *AccessMeta) {
+	typ := baseType(reflect.TypeOf((*T)(nil)).Elem())
+	acc.RelWrites = append(acc.RelWrites, typ)
+}
+
+// AccessCommands marks the system as recording deferred Commands, which are
+// applied at a sync point after the system's batch. The types the commands
+// write are declared separately with AccessWrite and AccessResWrite.
+func AccessCommands(acc *AccessMeta) {
+	acc.Commands = true
+}
+
+// AccessExclusive marks the system as requiring exclusive world access.
+func AccessExclusive(acc *AccessMeta) {
+	acc.Exclusive = true
+}
+
+// MergeAccess merges src into dst. Archetype filters are intersected, since the
+// merged access is only restricted by what both sides have in common.
 func MergeAccess(dst, src *AccessMeta) {
+	dstComps := len(dst.Reads) > 0 || len(dst.Writes) > 0
+	srcComps := len(src.Reads) > 0 || len(src.Writes) > 0
+	switch {
+	case dstComps && srcComps:
+		dst.With = intersectTypes(dst.With, src.With)
+		dst.Without = intersectTypes(dst.Without, src.Without)
+	case srcComps:
+		dst.With = append([]reflect.Type(nil), src.With...)
+		dst.Without = append([]reflect.Type(nil), src.Without...)
+	}
+
 	dst.Reads = append(dst.Reads, src.Reads...)
 	dst.Writes = append(dst.Writes, src.Writes...)
 	dst.ResReads = append(dst.ResReads, src.ResReads...)
 	dst.ResWrites = append(dst.ResWrites, src.ResWrites...)
 	dst.EventReads = append(dst.EventReads, src.EventReads...)
 	dst.EventWrites = append(dst.EventWrites, src.EventWrites...)
+	dst.EventMutates = append(dst.EventMutates, src.EventMutates...)
+	if len(src.EventPriorities) > 0 && dst.EventPriorities == nil {
+		dst.EventPriorities = make(map[reflect.Type]EventPriority, len(src.EventPriorities))
+	}
+	maps.Copy(dst.EventPriorities, src.EventPriorities)
+	dst.RelReads = append(dst.RelReads, src.RelReads...)
+	dst.RelWrites = append(dst.RelWrites, src.RelWrites...)
+	dst.Commands = dst.Commands || src.Commands
+	dst.Exclusive = dst.Exclusive || src.Exclusive
 }
 
 func (a AccessMeta) toInternal() scheduler.AccessMeta {
+	var prios map[reflect.Type]int
+	if len(a.EventPriorities) > 0 {
+		prios = make(map[reflect.Type]int, len(a.EventPriorities))
+		for t, p := range a.EventPriorities {
+			prios[t] = int(p)
+		}
+	}
 	return scheduler.AccessMeta{
-		Reads:       a.Reads,
-		Writes:      a.Writes,
-		ResReads:    a.ResReads,
-		ResWrites:   a.ResWrites,
-		EventReads:  a.EventReads,
-		EventWrites: a.EventWrites,
+		Reads:           a.Reads,
+		Writes:          a.Writes,
+		ResReads:        a.ResReads,
+		ResWrites:       a.ResWrites,
+		EventReads:      a.EventReads,
+		EventWrites:     a.EventWrites,
+		EventMutates:    a.EventMutates,
+		With:            a.With,
+		EventPriorities: prios,
+		Without:         a.Without,
+		RelReads:        a.RelReads,
+		RelWrites:       a.RelWrites,
+		Commands:        a.Commands,
+		Exclusive:       a.Exclusive,
 	}
 }
 
@@ -105,6 +250,17 @@ func (a SystemMeta) toInternal() scheduler.SystemMeta {
 	}
 }
 
+// intersectTypes returns the types present in both a and b.
+func intersectTypes(a, b []reflect.Type) []reflect.Type {
+	var out []reflect.Type
+	for _, t := range a {
+		if slices.Contains(b, t) && !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
 // baseType returns the non-pointer base reflect.Type and is the canonical helper for this package.
 func baseType(t reflect.Type) reflect.Type {
 	for t.Kind() == reflect.Ptr {
@@ -0,0 +1,243 @@
+package bevi
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mlange-42/ark/ecs"
+)
+
+// Lifecycle selects the component change a component observer reacts to.
+type Lifecycle int
+
+const (
+	// OnAdd fires after the component is added to an entity that did not have
+	// it, including when the entity is spawned with it.
+	OnAdd Lifecycle = iota
+	// OnInsert fires whenever a component value is written structurally: on
+	// add, on Commands.Add for a component the entity already has, and on
+	// Ark's Map.Set. Plain writes through queries do not fire it.
+	OnInsert
+	// OnRemove fires before the component is removed, including when the
+	// entity is despawned.
+	OnRemove
+)
+
+// String returns the lifecycle name.
+func (l Lifecycle) String() string {
+	switch l {
+	case OnAdd:
+		return "OnAdd"
+	case OnInsert:
+		return "OnInsert"
+	case OnRemove:
+		return "OnRemove"
+	default:
+		return "Unknown"
+	}
+}
+
+// Trigger is passed to observers of event type E.
+type Trigger[E any] struct {
+	Event E
+	// Target is the entity currently visited, or the zero Entity for
+	// untargeted triggers. It moves up the parent chain while propagating.
+	Target Entity
+	// Origin is the entity the event was triggered for.
+	Origin Entity
+	stop   bool
+}
+
+// StopPropagation prevents the event from propagating to the parent of the
+// current target. Remaining observers of the current target still run.
+func (t *Trigger[E]) StopPropagation() {
+	t.stop = true
+}
+
+// observers holds the App's event observers and the component hooks run by
+// Commands. Commands create entities and add components through Ark's unsafe
+// API before copying in the values, so Ark's own add events are muted while
+// they apply and the hooks run once the values are set.
+type observers struct {
+	mu        sync.RWMutex
+	events    map[reflect.Type]any                                 // []func(*World, *Trigger[E])
+	dispatch  map[reflect.Type]func(a *App, target Entity, ev any) // untyped entry for Commands
+	propagate map[reflect.Type]reflect.Type                        // event type -> relation component
+	adds      map[reflect.Type][]func(w *World, e Entity)          // component type -> OnAdd/OnInsert hooks
+	inserts   map[reflect.Type][]func(w *World, e Entity)          // component type -> OnInsert hooks
+	muted     atomic.Bool
+}
+
+// Observe registers fn to run synchronously whenever an event of type E is
+// triggered with TriggerEvent, TriggerFor, Commands.Trigger or
+// Commands.TriggerFor. Observers run in registration order.
+func Observe[E any](app *App, fn func(w *World, t *Trigger[E])) *App {
+	typ := reflect.TypeOf((*E)(nil)).Elem()
+	o := &app.observers
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.events == nil {
+		o.events = make(map[reflect.Type]any)
+		o.dispatch = make(map[reflect.Type]func(*App, Entity, any))
+	}
+	fns, _ := o.events[typ].([]func(*World, *Trigger[E]))
+	o.events[typ] = append(fns, fn)
+	o.dispatch[typ] = func(a *App, target Entity, ev any) {
+		TriggerFor(a, target, ev.(E))
+	}
+	return app
+}
+
+// Propagate makes entity-targeted events of type E propagate up the parent
+// chain formed by relation component R: after the observers ran for a target,
+// they run again for the target's R relation target, until an entity without
+// R is reached or an observer calls StopPropagation.
+func Propagate[E, R any](app *App) *App {
+	o := &app.observers
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.propagate == nil {
+		o.propagate = make(map[reflect.Type]reflect.Type)
+	}
+	o.propagate[reflect.TypeOf((*E)(nil)).Elem()] = reflect.TypeOf((*R)(nil)).Elem()
+	return app
+}
+
+// TriggerEvent runs the observers of E for an untargeted event. Observers run
+// on the calling goroutine and may change the world, so the caller must have
+// exclusive world access: outside Run, in an exclusive or one-shot system, or
+// in an observer. Use Commands.Trigger from regular systems.
+func TriggerEvent[E any](app *App, ev E) {
+	TriggerFor(app, Entity{}, ev)
+}
+
+// TriggerFor runs the observers of E for an event targeting the given entity,
+// propagating it if configured with Propagate. The same access rules as for
+// TriggerEvent apply.
+func TriggerFor[E any](app *App, target Entity, ev E) {
+	typ := reflect.TypeOf((*E)(nil)).Elem()
+	o := &app.observers
+	o.mu.RLock()
+	fns, _ := o.events[typ].([]func(*World, *Trigger[E]))
+	rel, propagate := o.propagate[typ]
+	o.mu.RUnlock()
+	if len(fns) == 0 {
+		return
+	}
+
+	w := app.world
+	t := &Trigger[E]{Event: ev, Target: target, Origin: target}
+	var visited []Entity
+	for {
+		for _, fn := range fns {
+			fn(w, t)
+		}
+		if !propagate || t.stop || t.Target.IsZero() || !w.Alive(t.Target) {
+			return
+		}
+		id := ecs.TypeID(w, rel)
+		u := w.Unsafe()
+		if !u.Has(t.Target, id) {
+			return
+		}
+		visited = append(visited, t.Target)
+		parent := u.GetRelation(t.Target, id)
+		if parent.IsZero() || !w.Alive(parent) || slices.Contains(visited, parent) {
+			return
+		}
+		t.Target = parent
+	}
+}
+
+// ObserveComponent registers fn to run synchronously when component C goes
+// through the given lifecycle change. Structural changes recorded with
+// Commands fire at the sync point that applies them. fn must not add or
+// remove components of the entity.
+func ObserveComponent[C any](app *App, kind Lifecycle, fn func(w *World, e Entity, c *C)) *App {
+	w := app.world
+	o := &app.observers
+	do := func(e Entity, c *C) {
+		if !o.muted.Load() {
+			fn(w, e, c)
+		}
+	}
+	switch kind {
+	case OnAdd, OnInsert:
+		ecs.Observe1[C](ecs.OnCreateEntity).Do(do).Register(w)
+		ecs.Observe1[C](ecs.OnAddComponents).Do(do).Register(w)
+		typ := reflect.TypeOf((*C)(nil)).Elem()
+		id := ecs.ComponentID[C](w)
+		hook := func(w *World, e Entity) {
+			fn(w, e, (*C)(w.Unsafe().Get(e, id)))
+		}
+		o.mu.Lock()
+		o.adds = addHook(o.adds, typ, hook)
+		if kind == OnInsert {
+			o.inserts = addHook(o.inserts, typ, hook)
+		}
+		o.mu.Unlock()
+		if kind == OnInsert {
+			ecs.Observe1[C](ecs.OnSetComponents).Do(do).Register(w)
+		}
+	case OnRemove:
+		ecs.Observe1[C](ecs.OnRemoveEntity).Do(do).Register(w)
+		ecs.Observe1[C](ecs.OnRemoveComponents).Do(do).Register(w)
+	}
+	return app
+}
+
+// trigger dispatches an event recorded by Commands.Trigger/TriggerFor.
+func (o *observers) trigger(a *App, target Entity, ev any) {
+	o.mu.RLock()
+	fn := o.dispatch[reflect.TypeOf(ev)]
+	o.mu.RUnlock()
+	if fn != nil {
+		fn(a, target, ev)
+	}
+}
+
+// structural runs fn, a Commands change creating an entity or adding
+// components, with Ark's add events muted.
+func (o *observers) structural(fn func()) {
+	o.muted.Store(true)
+	defer o.muted.Store(false)
+	fn()
+}
+
+// hooked reports whether any component hooks are registered.
+func (o *observers) hooked() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.adds) > 0 || len(o.inserts) > 0
+}
+
+// applied runs the hooks for components added and replaced by Commands once
+// their values have been set.
+func (o *observers) applied(w *World, e Entity, added, replaced []reflect.Type) {
+	o.run(o.adds, w, e, added)
+	o.run(o.inserts, w, e, replaced)
+}
+
+func (o *observers) run(hooks map[reflect.Type][]func(*World, Entity), w *World, e Entity, types []reflect.Type) {
+	for _, t := range types {
+		o.mu.RLock()
+		fns := hooks[t]
+		o.mu.RUnlock()
+		for _, fn := range fns {
+			if !w.Alive(e) {
+				return
+			}
+			fn(w, e)
+		}
+	}
+}
+
+func addHook(m map[reflect.Type][]func(*World, Entity), t reflect.Type, fn func(*World, Entity)) map[reflect.Type][]func(*World, Entity) {
+	if m == nil {
+		m = make(map[reflect.Type][]func(*World, Entity))
+	}
+	m[t] = append(m[t], fn)
+	return m
+}
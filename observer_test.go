@@ -0,0 +1,199 @@
+package bevi_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/oriumgames/bevi"
+)
+
+type clicked struct{ Button int }
+
+type childOf struct{ bevi.RelationMarker }
+
+type health struct{ HP int }
+
+// newTestApp returns an App with a no-op one-shot system, so flush can apply
+// the commands recorded on app.Commands().
+func newTestApp() *bevi.App {
+	app := bevi.NewApp()
+	app.AddSystem(bevi.OneShot, "flush", bevi.SystemMeta{}, func(context.Context, *bevi.World) {})
+	return app
+}
+
+func flush(t *testing.T, app *bevi.App) {
+	t.Helper()
+	if err := app.RunSystem("flush", nil); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+}
+
+// Test that observers run in registration order, right away for
+// TriggerEvent and at the next sync point for Commands.Trigger.
+func TestObserveTrigger(t *testing.T) {
+	app := newTestApp()
+	var got []string
+	bevi.Observe(app, func(_ *bevi.World, tr *bevi.Trigger[clicked]) {
+		got = append(got, fmt.Sprintf("first %d", tr.Event.Button))
+		if !tr.Target.IsZero() || !tr.Origin.IsZero() {
+			t.Errorf("expected an untargeted trigger, got %v/%v", tr.Target, tr.Origin)
+		}
+	})
+	bevi.Observe(app, func(_ *bevi.World, tr *bevi.Trigger[clicked]) {
+		got = append(got, fmt.Sprintf("second %d", tr.Event.Button))
+	})
+
+	bevi.TriggerEvent(app, clicked{Button: 1})
+	if want := []string{"first 1", "second 1"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	got = nil
+	app.Commands().Trigger(clicked{Button: 2})
+	if len(got) != 0 {
+		t.Fatalf("expected Commands.Trigger to wait for the sync point, got %v", got)
+	}
+	flush(t, app)
+	if want := []string{"first 2", "second 2"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// Targeted triggers for entities despawned before the sync point are dropped.
+	got = nil
+	e := app.World().NewEntity()
+	app.Commands().Despawn(e)
+	app.Commands().TriggerFor(e, clicked{Button: 3})
+	flush(t, app)
+	if len(got) != 0 {
+		t.Fatalf("expected the trigger for a despawned entity to be dropped, got %v", got)
+	}
+}
+
+// Test that targeted events propagate up the relation chain until an entity
+// without the relation or StopPropagation, keeping their origin.
+func TestObservePropagation(t *testing.T) {
+	app := newTestApp()
+	w := app.World()
+	rel := ecs.NewMap[childOf](w)
+	root := w.NewEntity()
+	panel := rel.NewEntity(&childOf{}, root)
+	button := rel.NewEntity(&childOf{}, panel)
+
+	var targets []bevi.Entity
+	stopAt := bevi.Entity{}
+	bevi.Observe(app, func(_ *bevi.World, tr *bevi.Trigger[clicked]) {
+		targets = append(targets, tr.Target)
+		if tr.Origin != button {
+			t.Errorf("Origin = %v, want %v", tr.Origin, button)
+		}
+		if tr.Target == stopAt {
+			tr.StopPropagation()
+		}
+	})
+
+	// Without Propagate, only the target is visited.
+	bevi.TriggerFor(app, button, clicked{})
+	if want := []bevi.Entity{button}; !slices.Equal(targets, want) {
+		t.Fatalf("got %v, want %v", targets, want)
+	}
+
+	bevi.Propagate[clicked, childOf](app)
+	targets = nil
+	bevi.TriggerFor(app, button, clicked{})
+	if want := []bevi.Entity{button, panel, root}; !slices.Equal(targets, want) {
+		t.Fatalf("got %v, want %v", targets, want)
+	}
+
+	targets = nil
+	stopAt = panel
+	bevi.TriggerFor(app, button, clicked{})
+	if want := []bevi.Entity{button, panel}; !slices.Equal(targets, want) {
+		t.Fatalf("got %v, want %v", targets, want)
+	}
+}
+
+// Test that propagation visits every entity of a relation cycle once.
+func TestObservePropagationCycle(t *testing.T) {
+	app := newTestApp()
+	w := app.World()
+	rel := ecs.NewMap[childOf](w)
+	bevi.Propagate[clicked, childOf](app)
+
+	var targets []bevi.Entity
+	bevi.Observe(app, func(_ *bevi.World, tr *bevi.Trigger[clicked]) {
+		targets = append(targets, tr.Target)
+	})
+
+	self := w.NewEntity()
+	rel.Add(self, &childOf{}, self)
+	bevi.TriggerFor(app, self, clicked{})
+	if want := []bevi.Entity{self}; !slices.Equal(targets, want) {
+		t.Fatalf("self-parented: got %v, want %v", targets, want)
+	}
+
+	a := w.NewEntity()
+	b := rel.NewEntity(&childOf{}, a)
+	rel.Add(a, &childOf{}, b)
+	targets = nil
+	bevi.TriggerFor(app, a, clicked{})
+	if want := []bevi.Entity{a, b}; !slices.Equal(targets, want) {
+		t.Fatalf("2-cycle: got %v, want %v", targets, want)
+	}
+}
+
+// Test the order and values seen by component hooks for changes recorded
+// with Commands: spawn, add to an entity that has the component, remove and
+// despawn.
+func TestComponentHooks(t *testing.T) {
+	app := newTestApp()
+	var got []string
+	hook := func(name string) func(*bevi.World, bevi.Entity, *health) {
+		return func(_ *bevi.World, _ bevi.Entity, h *health) {
+			got = append(got, fmt.Sprintf("%s %d", name, h.HP))
+		}
+	}
+	bevi.ObserveComponent(app, bevi.OnAdd, hook("add"))
+	bevi.ObserveComponent(app, bevi.OnInsert, hook("insert"))
+	bevi.ObserveComponent(app, bevi.OnRemove, hook("remove"))
+
+	var e bevi.Entity
+	app.Commands().SpawnFn(func(_ *bevi.World, spawned bevi.Entity) { e = spawned }, &health{HP: 10})
+	if len(got) != 0 {
+		t.Fatalf("expected hooks to wait for the sync point, got %v", got)
+	}
+	flush(t, app)
+	if want := []string{"add 10", "insert 10"}; !slices.Equal(got, want) {
+		t.Fatalf("spawn: got %v, want %v", got, want)
+	}
+
+	got = nil
+	app.Commands().Add(e, health{HP: 20})
+	flush(t, app)
+	if want := []string{"insert 20"}; !slices.Equal(got, want) {
+		t.Fatalf("replace: got %v, want %v", got, want)
+	}
+
+	got = nil
+	app.Commands().Remove(e, bevi.C[health]())
+	flush(t, app)
+	if want := []string{"remove 20"}; !slices.Equal(got, want) {
+		t.Fatalf("remove: got %v, want %v", got, want)
+	}
+
+	got = nil
+	app.Commands().Add(e, health{HP: 30})
+	flush(t, app)
+	if want := []string{"add 30", "insert 30"}; !slices.Equal(got, want) {
+		t.Fatalf("add: got %v, want %v", got, want)
+	}
+
+	got = nil
+	app.Commands().Despawn(e)
+	flush(t, app)
+	if want := []string{"remove 30"}; !slices.Equal(got, want) {
+		t.Fatalf("despawn: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package bevi
+
+import "context"
+
+// In carries the input passed to a one-shot system through App.RunSystem,
+// App.QueueSystem or Commands.RunSystem. Generated systems receive it by
+// declaring a bevi.In[T] parameter.
+type In[T any] struct {
+	Value T
+	// Ok is false when the system was run without an input of type T.
+	Ok bool
+}
+
+type systemInputKey struct{}
+
+// withSystemInput returns a context carrying a one-shot system's input.
+func withSystemInput(ctx context.Context, input any) context.Context {
+	if input == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, systemInputKey{}, input)
+}
+
+// InputFrom extracts the one-shot input of type T from a system's context.
+// The input must have been passed as exactly T.
+func InputFrom[T any](ctx context.Context) In[T] {
+	v, ok := ctx.Value(systemInputKey{}).(T)
+	return In[T]{Value: v, Ok: ok}
+}
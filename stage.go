@@ -16,6 +16,9 @@ const (
 	Update
 	// PostUpdate runs once after the main Update stage for cleanup or finalization.
 	PostUpdate
+	// OneShot marks a system that belongs to no stage. It only runs when
+	// invoked through App.RunSystem, App.QueueSystem or Commands.RunSystem.
+	OneShot
 )
 
 // String returns the string representation of a stage.
@@ -33,6 +36,8 @@ func (s Stage) String() string {
 		return "Update"
 	case PostUpdate:
 		return "PostUpdate"
+	case OneShot:
+		return "OneShot"
 	default:
 		return "Unknown"
 	}
@@ -9,6 +9,15 @@ type Component = ecs.Comp
 type Batch = ecs.Batch
 type Entity = ecs.Entity
 type Relation = ecs.Relation
+type RelationMarker = ecs.RelationMarker
+
+func Rel[C any](target Entity) Relation {
+	return ecs.Rel[C](target)
+}
+
+func RelIdx(index int, target Entity) Relation {
+	return ecs.RelIdx(index, target)
+}
 
 func C[T any]() Component {
 	return ecs.C[T]()
@@ -151,18 +160,93 @@ func NewMap12[A, B, C, D, E, F, G, H, I, J, K, L any](app *App) *Map12[A, B, C,
 	return ecs.NewMap12[A, B, C, D, E, F, G, H, I, J, K, L](app.world)
 }
 
+type Filter0 struct {
+	*ecs.Filter0
+	ch *changeFilter
+}
+
+func NewFilter0(app *App) *Filter0 {
+	return &Filter0{Filter0: ecs.NewFilter0(app.world), ch: newChangeFilter(app)}
+}
+
+func (f *Filter0) Query(rel ...ecs.Relation) Query0 {
+	q := f.Filter0.Query(rel...)
+	c := false
+	return Query0{Query0: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter0) Added(comps ...Component) *Filter0 {
+	f.Filter0 = f.Filter0.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter0) Changed(comps ...Component) *Filter0 {
+	f.Filter0 = f.Filter0.With(comps...)
+	f.ch.addChanged(comps)
+	return f
+}
+
+func (f *Filter0) With(comps ...Component) *Filter0 {
+	f.Filter0 = f.Filter0.With(comps...)
+	return f
+}
+
+func (f *Filter0) Without(comps ...Component) *Filter0 {
+	f.Filter0 = f.Filter0.Without(comps...)
+	return f
+}
+
+func (f *Filter0) Relations(rel ...Relation) *Filter0 {
+	f.Filter0 = f.Filter0.Relations(rel...)
+	return f
+}
+
+func (f *Filter0) Exclusive() *Filter0 {
+	f.Filter0 = f.Filter0.Exclusive()
+	return f
+}
+
+func (f *Filter0) Register() *Filter0 {
+	f.Filter0 = f.Filter0.Register()
+	return f
+}
+
 type Filter1[A any] struct {
 	*ecs.Filter1[A]
+	ch *changeFilter
 }
 
 func NewFilter1[A any](app *App) *Filter1[A] {
-	return &Filter1[A]{Filter1: ecs.NewFilter1[A](app.world)}
+	return &Filter1[A]{Filter1: ecs.NewFilter1[A](app.world), ch: newChangeFilter(app, ecs.C[A]())}
 }
 
 func (f *Filter1[A]) Query(rel ...ecs.Relation) Query1[A] {
 	q := f.Filter1.Query(rel...)
 	c := false
-	return Query1[A]{Query1: &q, closed: &c}
+	return Query1[A]{Query1: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter1[A]) Added(comps ...Component) *Filter1[A] {
+	f.Filter1 = f.Filter1.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter1[A]) Changed(comps ...Component) *Filter1[A] {
+	f.Filter1 = f.Filter1.With(comps...)
+	f.ch.addChanged(comps)
+	return f
 }
 
 func (f *Filter1[A]) With(comps ...Component) *Filter1[A] {
@@ -175,18 +259,51 @@ func (f *Filter1[A]) Without(comps ...Component) *Filter1[A] {
 	return f
 }
 
+func (f *Filter1[A]) Relations(rel ...Relation) *Filter1[A] {
+	f.Filter1 = f.Filter1.Relations(rel...)
+	return f
+}
+
+func (f *Filter1[A]) Exclusive() *Filter1[A] {
+	f.Filter1 = f.Filter1.Exclusive()
+	return f
+}
+
+func (f *Filter1[A]) Register() *Filter1[A] {
+	f.Filter1 = f.Filter1.Register()
+	return f
+}
+
 type Filter2[A, B any] struct {
 	*ecs.Filter2[A, B]
+	ch *changeFilter
 }
 
 func NewFilter2[A, B any](app *App) *Filter2[A, B] {
-	return &Filter2[A, B]{Filter2: ecs.NewFilter2[A, B](app.world)}
+	return &Filter2[A, B]{Filter2: ecs.NewFilter2[A, B](app.world), ch: newChangeFilter(app, ecs.C[A](), ecs.C[B]())}
 }
 
 func (f *Filter2[A, B]) Query(rel ...ecs.Relation) Query2[A, B] {
 	q := f.Filter2.Query(rel...)
 	c := false
-	return Query2[A, B]{Query2: &q, closed: &c}
+	return Query2[A, B]{Query2: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter2[A, B]) Added(comps ...Component) *Filter2[A, B] {
+	f.Filter2 = f.Filter2.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter2[A, B]) Changed(comps ...Component) *Filter2[A, B] {
+	f.Filter2 = f.Filter2.With(comps...)
+	f.ch.addChanged(comps)
+	return f
 }
 
 func (f *Filter2[A, B]) With(comps ...Component) *Filter2[A, B] {
@@ -199,18 +316,51 @@ func (f *Filter2[A, B]) Without(comps ...Component) *Filter2[A, B] {
 	return f
 }
 
+func (f *Filter2[A, B]) Relations(rel ...Relation) *Filter2[A, B] {
+	f.Filter2 = f.Filter2.Relations(rel...)
+	return f
+}
+
+func (f *Filter2[A, B]) Exclusive() *Filter2[A, B] {
+	f.Filter2 = f.Filter2.Exclusive()
+	return f
+}
+
+func (f *Filter2[A, B]) Register() *Filter2[A, B] {
+	f.Filter2 = f.Filter2.Register()
+	return f
+}
+
 type Filter3[A, B, C any] struct {
 	*ecs.Filter3[A, B, C]
+	ch *changeFilter
 }
 
 func NewFilter3[A, B, C any](app *App) *Filter3[A, B, C] {
-	return &Filter3[A, B, C]{Filter3: ecs.NewFilter3[A, B, C](app.world)}
+	return &Filter3[A, B, C]{Filter3: ecs.NewFilter3[A, B, C](app.world), ch: newChangeFilter(app, ecs.C[A](), ecs.C[B](), ecs.C[C]())}
 }
 
 func (f *Filter3[A, B, C]) Query(rel ...ecs.Relation) Query3[A, B, C] {
 	q := f.Filter3.Query(rel...)
 	c := false
-	return Query3[A, B, C]{Query3: &q, closed: &c}
+	return Query3[A, B, C]{Query3: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter3[A, B, C]) Added(comps ...Component) *Filter3[A, B, C] {
+	f.Filter3 = f.Filter3.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter3[A, B, C]) Changed(comps ...Component) *Filter3[A, B, C] {
+	f.Filter3 = f.Filter3.With(comps...)
+	f.ch.addChanged(comps)
+	return f
 }
 
 func (f *Filter3[A, B, C]) With(comps ...Component) *Filter3[A, B, C] {
@@ -223,18 +373,51 @@ func (f *Filter3[A, B, C]) Without(comps ...Component) *Filter3[A, B, C] {
 	return f
 }
 
+func (f *Filter3[A, B, C]) Relations(rel ...Relation) *Filter3[A, B, C] {
+	f.Filter3 = f.Filter3.Relations(rel...)
+	return f
+}
+
+func (f *Filter3[A, B, C]) Exclusive() *Filter3[A, B, C] {
+	f.Filter3 = f.Filter3.Exclusive()
+	return f
+}
+
+func (f *Filter3[A, B, C]) Register() *Filter3[A, B, C] {
+	f.Filter3 = f.Filter3.Register()
+	return f
+}
+
 type Filter4[A, B, C, D any] struct {
 	*ecs.Filter4[A, B, C, D]
+	ch *changeFilter
 }
 
 func NewFilter4[A, B, C, D any](app *App) *Filter4[A, B, C, D] {
-	return &Filter4[A, B, C, D]{Filter4: ecs.NewFilter4[A, B, C, D](app.world)}
+	return &Filter4[A, B, C, D]{Filter4: ecs.NewFilter4[A, B, C, D](app.world), ch: newChangeFilter(app, ecs.C[A](), ecs.C[B](), ecs.C[C](), ecs.C[D]())}
 }
 
 func (f *Filter4[A, B, C, D]) Query(rel ...ecs.Relation) Query4[A, B, C, D] {
 	q := f.Filter4.Query(rel...)
 	c := false
-	return Query4[A, B, C, D]{Query4: &q, closed: &c}
+	return Query4[A, B, C, D]{Query4: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter4[A, B, C, D]) Added(comps ...Component) *Filter4[A, B, C, D] {
+	f.Filter4 = f.Filter4.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter4[A, B, C, D]) Changed(comps ...Component) *Filter4[A, B, C, D] {
+	f.Filter4 = f.Filter4.With(comps...)
+	f.ch.addChanged(comps)
+	return f
 }
 
 func (f *Filter4[A, B, C, D]) With(comps ...Component) *Filter4[A, B, C, D] {
@@ -247,18 +430,51 @@ func (f *Filter4[A, B, C, D]) Without(comps ...Component) *Filter4[A, B, C, D] {
 	return f
 }
 
+func (f *Filter4[A, B, C, D]) Relations(rel ...Relation) *Filter4[A, B, C, D] {
+	f.Filter4 = f.Filter4.Relations(rel...)
+	return f
+}
+
+func (f *Filter4[A, B, C, D]) Exclusive() *Filter4[A, B, C, D] {
+	f.Filter4 = f.Filter4.Exclusive()
+	return f
+}
+
+func (f *Filter4[A, B, C, D]) Register() *Filter4[A, B, C, D] {
+	f.Filter4 = f.Filter4.Register()
+	return f
+}
+
 type Filter5[A, B, C, D, E any] struct {
 	*ecs.Filter5[A, B, C, D, E]
+	ch *changeFilter
 }
 
 func NewFilter5[A, B, C, D, E any](app *App) *Filter5[A, B, C, D, E] {
-	return &Filter5[A, B, C, D, E]{Filter5: ecs.NewFilter5[A, B, C, D, E](app.world)}
+	return &Filter5[A, B, C, D, E]{Filter5: ecs.NewFilter5[A, B, C, D, E](app.world), ch: newChangeFilter(app, ecs.C[A](), ecs.C[B](), ecs.C[C](), ecs.C[D](), ecs.C[E]())}
 }
 
 func (f *Filter5[A, B, C, D, E]) Query(rel ...ecs.Relation) Query5[A, B, C, D, E] {
 	q := f.Filter5.Query(rel...)
 	c := false
-	return Query5[A, B, C, D, E]{Query5: &q, closed: &c}
+	return Query5[A, B, C, D, E]{Query5: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter5[A, B, C, D, E]) Added(comps ...Component) *Filter5[A, B, C, D, E] {
+	f.Filter5 = f.Filter5.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter5[A, B, C, D, E]) Changed(comps ...Component) *Filter5[A, B, C, D, E] {
+	f.Filter5 = f.Filter5.With(comps...)
+	f.ch.addChanged(comps)
+	return f
 }
 
 func (f *Filter5[A, B, C, D, E]) With(comps ...Component) *Filter5[A, B, C, D, E] {
@@ -271,18 +487,51 @@ func (f *Filter5[A, B, C, D, E]) Without(comps ...Component) *Filter5[A, B, C, D
 	return f
 }
 
+func (f *Filter5[A, B, C, D, E]) Relations(rel ...Relation) *Filter5[A, B, C, D, E] {
+	f.Filter5 = f.Filter5.Relations(rel...)
+	return f
+}
+
+func (f *Filter5[A, B, C, D, E]) Exclusive() *Filter5[A, B, C, D, E] {
+	f.Filter5 = f.Filter5.Exclusive()
+	return f
+}
+
+func (f *Filter5[A, B, C, D, E]) Register() *Filter5[A, B, C, D, E] {
+	f.Filter5 = f.Filter5.Register()
+	return f
+}
+
 type Filter6[A, B, C, D, E, F any] struct {
 	*ecs.Filter6[A, B, C, D, E, F]
+	ch *changeFilter
 }
 
 func NewFilter6[A, B, C, D, E, F any](app *App) *Filter6[A, B, C, D, E, F] {
-	return &Filter6[A, B, C, D, E, F]{Filter6: ecs.NewFilter6[A, B, C, D, E, F](app.world)}
+	return &Filter6[A, B, C, D, E, F]{Filter6: ecs.NewFilter6[A, B, C, D, E, F](app.world), ch: newChangeFilter(app, ecs.C[A](), ecs.C[B](), ecs.C[C](), ecs.C[D](), ecs.C[E](), ecs.C[F]())}
 }
 
 func (f *Filter6[A, B, C, D, E, F]) Query(rel ...ecs.Relation) Query6[A, B, C, D, E, F] {
 	q := f.Filter6.Query(rel...)
 	c := false
-	return Query6[A, B, C, D, E, F]{Query6: &q, closed: &c}
+	return Query6[A, B, C, D, E, F]{Query6: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter6[A, B, C, D, E, F]) Added(comps ...Component) *Filter6[A, B, C, D, E, F] {
+	f.Filter6 = f.Filter6.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter6[A, B, C, D, E, F]) Changed(comps ...Component) *Filter6[A, B, C, D, E, F] {
+	f.Filter6 = f.Filter6.With(comps...)
+	f.ch.addChanged(comps)
+	return f
 }
 
 func (f *Filter6[A, B, C, D, E, F]) With(comps ...Component) *Filter6[A, B, C, D, E, F] {
@@ -295,18 +544,51 @@ func (f *Filter6[A, B, C, D, E, F]) Without(comps ...Component) *Filter6[A, B, C
 	return f
 }
 
+func (f *Filter6[A, B, C, D, E, F]) Relations(rel ...Relation) *Filter6[A, B, C, D, E, F] {
+	f.Filter6 = f.Filter6.Relations(rel...)
+	return f
+}
+
+func (f *Filter6[A, B, C, D, E, F]) Exclusive() *Filter6[A, B, C, D, E, F] {
+	f.Filter6 = f.Filter6.Exclusive()
+	return f
+}
+
+func (f *Filter6[A, B, C, D, E, F]) Register() *Filter6[A, B, C, D, E, F] {
+	f.Filter6 = f.Filter6.Register()
+	return f
+}
+
 type Filter7[A, B, C, D, E, F, G any] struct {
 	*ecs.Filter7[A, B, C, D, E, F, G]
+	ch *changeFilter
 }
 
 func NewFilter7[A, B, C, D, E, F, G any](app *App) *Filter7[A, B, C, D, E, F, G] {
-	return &Filter7[A, B, C, D, E, F, G]{Filter7: ecs.NewFilter7[A, B, C, D, E, F, G](app.world)}
+	return &Filter7[A, B, C, D, E, F, G]{Filter7: ecs.NewFilter7[A, B, C, D, E, F, G](app.world), ch: newChangeFilter(app, ecs.C[A](), ecs.C[B](), ecs.C[C](), ecs.C[D](), ecs.C[E](), ecs.C[F](), ecs.C[G]())}
 }
 
 func (f *Filter7[A, B, C, D, E, F, G]) Query(rel ...ecs.Relation) Query7[A, B, C, D, E, F, G] {
 	q := f.Filter7.Query(rel...)
 	c := false
-	return Query7[A, B, C, D, E, F, G]{Query7: &q, closed: &c}
+	return Query7[A, B, C, D, E, F, G]{Query7: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter7[A, B, C, D, E, F, G]) Added(comps ...Component) *Filter7[A, B, C, D, E, F, G] {
+	f.Filter7 = f.Filter7.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter7[A, B, C, D, E, F, G]) Changed(comps ...Component) *Filter7[A, B, C, D, E, F, G] {
+	f.Filter7 = f.Filter7.With(comps...)
+	f.ch.addChanged(comps)
+	return f
 }
 
 func (f *Filter7[A, B, C, D, E, F, G]) With(comps ...Component) *Filter7[A, B, C, D, E, F, G] {
@@ -319,18 +601,51 @@ func (f *Filter7[A, B, C, D, E, F, G]) Without(comps ...Component) *Filter7[A, B
 	return f
 }
 
+func (f *Filter7[A, B, C, D, E, F, G]) Relations(rel ...Relation) *Filter7[A, B, C, D, E, F, G] {
+	f.Filter7 = f.Filter7.Relations(rel...)
+	return f
+}
+
+func (f *Filter7[A, B, C, D, E, F, G]) Exclusive() *Filter7[A, B, C, D, E, F, G] {
+	f.Filter7 = f.Filter7.Exclusive()
+	return f
+}
+
+func (f *Filter7[A, B, C, D, E, F, G]) Register() *Filter7[A, B, C, D, E, F, G] {
+	f.Filter7 = f.Filter7.Register()
+	return f
+}
+
 type Filter8[A, B, C, D, E, F, G, H any] struct {
 	*ecs.Filter8[A, B, C, D, E, F, G, H]
+	ch *changeFilter
 }
 
 func NewFilter8[A, B, C, D, E, F, G, H any](app *App) *Filter8[A, B, C, D, E, F, G, H] {
-	return &Filter8[A, B, C, D, E, F, G, H]{Filter8: ecs.NewFilter8[A, B, C, D, E, F, G, H](app.world)}
+	return &Filter8[A, B, C, D, E, F, G, H]{Filter8: ecs.NewFilter8[A, B, C, D, E, F, G, H](app.world), ch: newChangeFilter(app, ecs.C[A](), ecs.C[B](), ecs.C[C](), ecs.C[D](), ecs.C[E](), ecs.C[F](), ecs.C[G](), ecs.C[H]())}
 }
 
 func (f *Filter8[A, B, C, D, E, F, G, H]) Query(rel ...ecs.Relation) Query8[A, B, C, D, E, F, G, H] {
 	q := f.Filter8.Query(rel...)
 	c := false
-	return Query8[A, B, C, D, E, F, G, H]{Query8: &q, closed: &c}
+	return Query8[A, B, C, D, E, F, G, H]{Query8: &q, closed: &c, ch: f.ch.query()}
+}
+
+// Added restricts the filter to entities whose given components were added
+// since the filter's previous query, and enables change detection for them.
+func (f *Filter8[A, B, C, D, E, F, G, H]) Added(comps ...Component) *Filter8[A, B, C, D, E, F, G, H] {
+	f.Filter8 = f.Filter8.With(comps...)
+	f.ch.addAdded(comps)
+	return f
+}
+
+// Changed restricts the filter to entities whose given components were added
+// or changed since the filter's previous query, and enables change detection
+// for them.
+func (f *Filter8[A, B, C, D, E, F, G, H]) Changed(comps ...Component) *Filter8[A, B, C, D, E, F, G, H] {
+	f.Filter8 = f.Filter8.With(comps...)
+	f.ch.addChanged(comps)
+	return f
 }
 
 func (f *Filter8[A, B, C, D, E, F, G, H]) With(comps ...Component) *Filter8[A, B, C, D, E, F, G, H] {
@@ -343,9 +658,25 @@ func (f *Filter8[A, B, C, D, E, F, G, H]) Without(comps ...Component) *Filter8[A
 	return f
 }
 
+func (f *Filter8[A, B, C, D, E, F, G, H]) Relations(rel ...Relation) *Filter8[A, B, C, D, E, F, G, H] {
+	f.Filter8 = f.Filter8.Relations(rel...)
+	return f
+}
+
+func (f *Filter8[A, B, C, D, E, F, G, H]) Exclusive() *Filter8[A, B, C, D, E, F, G, H] {
+	f.Filter8 = f.Filter8.Exclusive()
+	return f
+}
+
+func (f *Filter8[A, B, C, D, E, F, G, H]) Register() *Filter8[A, B, C, D, E, F, G, H] {
+	f.Filter8 = f.Filter8.Register()
+	return f
+}
+
 type Query0 struct {
 	*ecs.Query0
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query0) Close() {
@@ -356,16 +687,19 @@ func (q Query0) Close() {
 }
 
 func (q Query0) Next() bool {
-	r := q.Query0.Next()
-	if !r {
-		*q.closed = true
+	for q.Query0.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
 }
 
 type Query1[A any] struct {
 	*ecs.Query1[A]
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query1[A]) Close() {
@@ -376,16 +710,26 @@ func (q Query1[A]) Close() {
 }
 
 func (q Query1[A]) Next() bool {
-	r := q.Query1.Next()
-	if !r {
-		*q.closed = true
+	for q.Query1.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
+}
+
+// Refs returns change-tracked access to the current entity's components.
+func (q Query1[A]) Refs() Ref[A] {
+	a := q.Get()
+	ent := q.Entity()
+	return newRef(q.ch, 0, ent, a)
 }
 
 type Query2[A, B any] struct {
 	*ecs.Query2[A, B]
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query2[A, B]) Close() {
@@ -396,16 +740,26 @@ func (q Query2[A, B]) Close() {
 }
 
 func (q Query2[A, B]) Next() bool {
-	r := q.Query2.Next()
-	if !r {
-		*q.closed = true
+	for q.Query2.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
+}
+
+// Refs returns change-tracked access to the current entity's components.
+func (q Query2[A, B]) Refs() (Ref[A], Ref[B]) {
+	a, b := q.Get()
+	ent := q.Entity()
+	return newRef(q.ch, 0, ent, a), newRef(q.ch, 1, ent, b)
 }
 
 type Query3[A, B, C any] struct {
 	*ecs.Query3[A, B, C]
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query3[A, B, C]) Close() {
@@ -416,16 +770,26 @@ func (q Query3[A, B, C]) Close() {
 }
 
 func (q Query3[A, B, C]) Next() bool {
-	r := q.Query3.Next()
-	if !r {
-		*q.closed = true
+	for q.Query3.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
+}
+
+// Refs returns change-tracked access to the current entity's components.
+func (q Query3[A, B, C]) Refs() (Ref[A], Ref[B], Ref[C]) {
+	a, b, c := q.Get()
+	ent := q.Entity()
+	return newRef(q.ch, 0, ent, a), newRef(q.ch, 1, ent, b), newRef(q.ch, 2, ent, c)
 }
 
 type Query4[A, B, C, D any] struct {
 	*ecs.Query4[A, B, C, D]
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query4[A, B, C, D]) Close() {
@@ -436,16 +800,26 @@ func (q Query4[A, B, C, D]) Close() {
 }
 
 func (q Query4[A, B, C, D]) Next() bool {
-	r := q.Query4.Next()
-	if !r {
-		*q.closed = true
+	for q.Query4.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
+}
+
+// Refs returns change-tracked access to the current entity's components.
+func (q Query4[A, B, C, D]) Refs() (Ref[A], Ref[B], Ref[C], Ref[D]) {
+	a, b, c, d := q.Get()
+	ent := q.Entity()
+	return newRef(q.ch, 0, ent, a), newRef(q.ch, 1, ent, b), newRef(q.ch, 2, ent, c), newRef(q.ch, 3, ent, d)
 }
 
 type Query5[A, B, C, D, E any] struct {
 	*ecs.Query5[A, B, C, D, E]
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query5[A, B, C, D, E]) Close() {
@@ -456,16 +830,26 @@ func (q Query5[A, B, C, D, E]) Close() {
 }
 
 func (q Query5[A, B, C, D, E]) Next() bool {
-	r := q.Query5.Next()
-	if !r {
-		*q.closed = true
+	for q.Query5.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
+}
+
+// Refs returns change-tracked access to the current entity's components.
+func (q Query5[A, B, C, D, E]) Refs() (Ref[A], Ref[B], Ref[C], Ref[D], Ref[E]) {
+	a, b, c, d, e := q.Get()
+	ent := q.Entity()
+	return newRef(q.ch, 0, ent, a), newRef(q.ch, 1, ent, b), newRef(q.ch, 2, ent, c), newRef(q.ch, 3, ent, d), newRef(q.ch, 4, ent, e)
 }
 
 type Query6[A, B, C, D, E, F any] struct {
 	*ecs.Query6[A, B, C, D, E, F]
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query6[A, B, C, D, E, F]) Close() {
@@ -476,16 +860,26 @@ func (q Query6[A, B, C, D, E, F]) Close() {
 }
 
 func (q Query6[A, B, C, D, E, F]) Next() bool {
-	r := q.Query6.Next()
-	if !r {
-		*q.closed = true
+	for q.Query6.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
+}
+
+// Refs returns change-tracked access to the current entity's components.
+func (q Query6[A, B, C, D, E, F]) Refs() (Ref[A], Ref[B], Ref[C], Ref[D], Ref[E], Ref[F]) {
+	a, b, c, d, e, f := q.Get()
+	ent := q.Entity()
+	return newRef(q.ch, 0, ent, a), newRef(q.ch, 1, ent, b), newRef(q.ch, 2, ent, c), newRef(q.ch, 3, ent, d), newRef(q.ch, 4, ent, e), newRef(q.ch, 5, ent, f)
 }
 
 type Query7[A, B, C, D, E, F, G any] struct {
 	*ecs.Query7[A, B, C, D, E, F, G]
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query7[A, B, C, D, E, F, G]) Close() {
@@ -496,16 +890,26 @@ func (q Query7[A, B, C, D, E, F, G]) Close() {
 }
 
 func (q Query7[A, B, C, D, E, F, G]) Next() bool {
-	r := q.Query7.Next()
-	if !r {
-		*q.closed = true
+	for q.Query7.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
+}
+
+// Refs returns change-tracked access to the current entity's components.
+func (q Query7[A, B, C, D, E, F, G]) Refs() (Ref[A], Ref[B], Ref[C], Ref[D], Ref[E], Ref[F], Ref[G]) {
+	a, b, c, d, e, f, g := q.Get()
+	ent := q.Entity()
+	return newRef(q.ch, 0, ent, a), newRef(q.ch, 1, ent, b), newRef(q.ch, 2, ent, c), newRef(q.ch, 3, ent, d), newRef(q.ch, 4, ent, e), newRef(q.ch, 5, ent, f), newRef(q.ch, 6, ent, g)
 }
 
 type Query8[A, B, C, D, E, F, G, H any] struct {
 	*ecs.Query8[A, B, C, D, E, F, G, H]
 	closed *bool
+	ch     *changeQuery
 }
 
 func (q Query8[A, B, C, D, E, F, G, H]) Close() {
@@ -516,9 +920,18 @@ func (q Query8[A, B, C, D, E, F, G, H]) Close() {
 }
 
 func (q Query8[A, B, C, D, E, F, G, H]) Next() bool {
-	r := q.Query8.Next()
-	if !r {
-		*q.closed = true
+	for q.Query8.Next() {
+		if q.ch.match(q.Entity()) {
+			return true
+		}
 	}
-	return r
+	*q.closed = true
+	return false
+}
+
+// Refs returns change-tracked access to the current entity's components.
+func (q Query8[A, B, C, D, E, F, G, H]) Refs() (Ref[A], Ref[B], Ref[C], Ref[D], Ref[E], Ref[F], Ref[G], Ref[H]) {
+	a, b, c, d, e, f, g, h := q.Get()
+	ent := q.Entity()
+	return newRef(q.ch, 0, ent, a), newRef(q.ch, 1, ent, b), newRef(q.ch, 2, ent, c), newRef(q.ch, 3, ent, d), newRef(q.ch, 4, ent, e), newRef(q.ch, 5, ent, f), newRef(q.ch, 6, ent, g), newRef(q.ch, 7, ent, h)
 }